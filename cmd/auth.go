@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"jira-release-manager/internal/credentials"
+	"jira-release-manager/internal/jira"
+	"jira-release-manager/internal/output"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Gestisce il token API salvato nel portachiavi di sistema.",
+	Long: `Salva il token API nel portachiavi di sistema (Secret Service su Linux, Keychain su macOS,
+Credential Manager su Windows), così non deve restare in chiaro nel file .env.
+Se JIRA_API_TOKEN non è impostata, il token salvato per JIRA_URL e JIRA_USERNAME viene usato
+automaticamente da tutti i comandi.
+Dove il portachiavi non è disponibile (es. CI headless) il token viene salvato in un file cifrato
+con la passphrase indicata in JIRA_CREDENTIALS_PASSPHRASE.`,
+	Annotations: map[string]string{annotationNoJiraClient: "true"},
+}
+
+var authLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Verifica un token API e lo salva nel portachiavi.",
+	Example: `  jira-release-manager auth login
+  jira-release-manager auth login --profile datacenter
+  echo "$TOKEN" | jira-release-manager auth login --token-stdin --store file`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		tokenStdin, _ := cmd.Flags().GetBool("token-stdin")
+		noVerify, _ := cmd.Flags().GetBool("no-verify")
+		if configErr != nil {
+			return configErr
+		}
+		// Da qui gli errori riguardano credenziali o archivio, non l'uso del comando
+		cmd.SilenceUsage = true
+
+		backend, err := credentialBackendFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		account, err := credentialAccount()
+		if err != nil {
+			return err
+		}
+
+		var token string
+		switch {
+		case tokenStdin:
+			content, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return fmt.Errorf("impossibile leggere il token da stdin: %w", err)
+			}
+			token = strings.TrimSpace(string(content))
+		case term.IsTerminal(int(os.Stdin.Fd())):
+			fmt.Fprintf(os.Stderr, "🔑 Token API per %s: ", account)
+			content, err := term.ReadPassword(int(os.Stdin.Fd()))
+			fmt.Fprintln(os.Stderr)
+			if err != nil {
+				return fmt.Errorf("impossibile leggere il token: %w", err)
+			}
+			token = strings.TrimSpace(string(content))
+		default:
+			return fmt.Errorf("nessun terminale disponibile: passa il token con --token-stdin")
+		}
+		if token == "" {
+			return fmt.Errorf("il token è vuoto")
+		}
+
+		if !noVerify {
+			fmt.Fprint(os.Stderr, "⏳ Verifica del token...")
+			viper.Set("JIRA_API_TOKEN", token)
+			client, err := jira.NewClient()
+			if err != nil {
+				fmt.Fprintln(os.Stderr, " ❌")
+				return err
+			}
+			user, err := jira.GetCurrentUser(ctx, client)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, " ❌")
+				return err
+			}
+			fmt.Fprintln(os.Stderr, " ✓")
+			fmt.Fprintf(os.Stderr, "✅ Accesso verificato come %s\n", userLabel(user))
+		}
+
+		used, err := credentials.Save(account, token, backend)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "✅ Token salvato %s per %s\n", backendLabel(used), account)
+
+		if _, set := os.LookupEnv("JIRA_API_TOKEN"); set {
+			fmt.Fprintln(os.Stderr, "⚠️  JIRA_API_TOKEN è impostata e ha la precedenza sul token salvato.")
+		}
+		return nil
+	},
+}
+
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Verifica le credenziali configurate contro /myself.",
+	Example: `  jira-release-manager auth status
+  jira-release-manager auth status --profile datacenter --output-format json`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		if configErr != nil {
+			return configErr
+		}
+		cmd.SilenceUsage = true
+
+		// La provenienza del token va determinata prima di NewClient, che lo risolve in autonomia
+		source := "JIRA_API_TOKEN (ambiente, .env o profilo)"
+		if viper.GetString("JIRA_API_TOKEN") == "" {
+			backend, err := credentialBackendFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			account, err := credentialAccount()
+			if err != nil {
+				return err
+			}
+			_, used, err := credentials.Lookup(account, backend)
+			if err != nil && !errors.Is(err, credentials.ErrNotFound) {
+				return err
+			}
+			source = backendLabel(used)
+		}
+
+		client, err := jira.NewClient()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "⏳ Verifica delle credenziali su %s...", client.BaseURL)
+		user, err := jira.GetCurrentUser(ctx, client)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, " ❌")
+			return err
+		}
+		fmt.Fprintln(os.Stderr, " ✓")
+
+		if outputFormat.IsStructured() {
+			return output.Write(os.Stdout, outputFormat, map[string]string{
+				"url":         client.BaseURL,
+				"deployment":  string(client.Deployment),
+				"authType":    string(client.AuthType),
+				"user":        userLabel(user),
+				"tokenSource": source,
+			})
+		}
+
+		fmt.Printf("✅ Autenticato come %s\n", userLabel(user))
+		fmt.Printf("   Jira:          %s (%s)\n", client.BaseURL, client.Deployment)
+		fmt.Printf("   Autenticazione: %s\n", client.AuthType)
+		fmt.Printf("   Token:         %s\n", source)
+		return nil
+	},
+}
+
+var authLogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Rimuove il token salvato per l'istanza Jira configurata.",
+	Example: `  jira-release-manager auth logout
+  jira-release-manager auth logout --profile datacenter`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if configErr != nil {
+			return configErr
+		}
+		cmd.SilenceUsage = true
+		account, err := credentialAccount()
+		if err != nil {
+			return err
+		}
+
+		removed, err := credentials.Delete(account)
+		if err != nil {
+			return err
+		}
+		if len(removed) == 0 {
+			fmt.Fprintf(os.Stderr, "ℹ️  Nessun token salvato per %s\n", account)
+			return nil
+		}
+		for _, backend := range removed {
+			fmt.Fprintf(os.Stderr, "✅ Token rimosso %s per %s\n", backendLabel(backend), account)
+		}
+		return nil
+	},
+}
+
+// credentialAccount restituisce l'account a cui associare il token, in base a JIRA_URL e JIRA_USERNAME
+func credentialAccount() (string, error) {
+	jiraURL := viper.GetString("JIRA_URL")
+	if jiraURL == "" {
+		return "", fmt.Errorf("JIRA_URL non è configurato: impostalo nell'ambiente, nel file .env o nel profilo")
+	}
+	return credentials.Account(jiraURL, viper.GetString("JIRA_USERNAME")), nil
+}
+
+// credentialBackendFromFlags restituisce l'archivio indicato con --store o JIRA_CREDENTIAL_STORE.
+// --store sostituisce JIRA_CREDENTIAL_STORE, così anche jira.NewClient legge il token dallo stesso archivio.
+func credentialBackendFromFlags(cmd *cobra.Command) (credentials.Backend, error) {
+	store, _ := cmd.Flags().GetString("store")
+	if store == "" {
+		store = viper.GetString("JIRA_CREDENTIAL_STORE")
+	}
+	backend, err := credentials.ParseBackend(store)
+	if err != nil {
+		return "", err
+	}
+	viper.Set("JIRA_CREDENTIAL_STORE", string(backend))
+	return backend, nil
+}
+
+// backendLabel descrive un archivio di credenziali
+func backendLabel(backend credentials.Backend) string {
+	switch backend {
+	case credentials.BackendKeyring:
+		return "nel portachiavi di sistema"
+	case credentials.BackendFile:
+		return "nel file cifrato"
+	default:
+		return "non trovato"
+	}
+}
+
+// userLabel restituisce il nome leggibile di un utente, con email o username se disponibili
+func userLabel(user *jira.User) string {
+	if user.DisplayName == "" {
+		if user.Name != "" {
+			return user.Name
+		}
+		return user.AccountID
+	}
+	switch {
+	case user.EmailAddress != "":
+		return fmt.Sprintf("%s <%s>", user.DisplayName, user.EmailAddress)
+	case user.Name != "":
+		return fmt.Sprintf("%s (%s)", user.DisplayName, user.Name)
+	default:
+		return user.DisplayName
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authLoginCmd, authStatusCmd, authLogoutCmd)
+	authCmd.PersistentFlags().String("store", "", "Archivio del token: keyring o file (default: il portachiavi, con il file cifrato come ripiego)")
+	authLoginCmd.Flags().Bool("token-stdin", false, "Legge il token da stdin invece di chiederlo")
+	authLoginCmd.Flags().Bool("no-verify", false, "Salva il token senza verificarlo su Jira")
+}
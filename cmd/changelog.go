@@ -1,51 +1,181 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"jira-release-manager/internal/jira"
 	"os"
+	"strings"
 
+	"jira-release-manager/internal/keepachangelog"
 	"jira-release-manager/internal/organizer"
+	"jira-release-manager/internal/output"
+	"jira-release-manager/internal/publish"
 	"jira-release-manager/internal/templates"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 var changelogCmd = &cobra.Command{
 	Use:   "changelog",
 	Short: "Genera un changelog in formato Markdown per una versione.",
 	Long: `Permette di selezionare interattivamente una versione e genera un changelog 
-formattato in Markdown (o altri formati) basato sui ticket.`,
+formattato in Markdown (o altri formati) basato sui ticket.
+Con --from e --to genera il changelog di un intervallo di versioni.`,
 	Example: `  jira-release-manager changelog -p PROJ
   jira-release-manager changelog -p PROJ --output CHANGELOG.md
-  jira-release-manager changelog -p PROJ --format teams`,
+  jira-release-manager changelog -p PROJ --format teams
+  jira-release-manager changelog -p PROJ --format slack
+  jira-release-manager changelog -p PROJ --format html --output release.html
+  jira-release-manager changelog -p PROJ --latest-released --status all --format keepachangelog --output CHANGELOG.md
+  jira-release-manager changelog -p PROJ --template release-notes.tmpl
+  jira-release-manager changelog -p PROJ --version 2.4.0
+  jira-release-manager changelog -p PROJ --latest-released --status all
+  jira-release-manager changelog -p PROJ --next --jql-filter "component = API"
+  jira-release-manager changelog -p PROJ --from 2.3.0 --to 2.5.0
+  jira-release-manager changelog -p PROJ --from 2.3.0 --to 2.5.0 --merged`,
 
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		outputFile, _ := cmd.Flags().GetString("output")
 		format, _ := cmd.Flags().GetString("format")
 		includeSubtasks, _ := cmd.Flags().GetBool("include-subtasks")
+		templateFile, _ := cmd.Flags().GetString("template")
+		fromSelector, _ := cmd.Flags().GetString("from")
+		toSelector, _ := cmd.Flags().GetString("to")
+		merged, _ := cmd.Flags().GetBool("merged")
 
-		versionToFetch, err := selectJiraVersion(jiraClient, projectKey)
+		filter, err := issueFilterFromFlags(cmd)
 		if err != nil {
 			return err
 		}
-		fmt.Printf("✅ Generazione changelog per la versione: %s\n", versionToFetch.Name)
+		// Un intervallo comprende di norma versioni già rilasciate, con i ticket tutti completati
+		if (fromSelector != "" || toSelector != "") && !cmd.Flags().Changed("status") {
+			filter.Status = jira.StatusAll
+		}
 
-		issues, err := jira.GetIssuesForVersion(jiraClient, projectKey, versionToFetch.Name)
-		if err != nil {
-			return fmt.Errorf("errore nel recupero dei ticket: %w", err)
+		// I formati slack e keepachangelog non passano da un template testuale
+		slack := templateFile == "" && format == "slack"
+		keepAChangelog := templateFile == "" && format == "keepachangelog" && !outputFormat.IsStructured()
+
+		var mapping keepachangelog.Mapping
+		if keepAChangelog {
+			if merged {
+				return fmt.Errorf("il formato keepachangelog non supporta --merged: ogni versione ha la propria sezione")
+			}
+			spec, _ := cmd.Flags().GetString("type-mapping")
+			if spec == "" {
+				spec = viper.GetString("CHANGELOG_TYPE_MAPPING")
+			}
+			if mapping, err = keepachangelog.ParseMapping(spec); err != nil {
+				return err
+			}
+		}
+
+		// Il template va validato prima di interrogare Jira
+		var tmpl *templates.Template
+		if !outputFormat.IsStructured() && !slack && !keepAChangelog {
+			if templateFile != "" {
+				tmpl, err = templates.FromFile(templateFile)
+			} else {
+				if format == "md" {
+					format = "markdown"
+				}
+				tmpl, err = templates.Builtin(format)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		// Un documento HTML non può contenere più documenti concatenati
+		if format == "html" && templateFile == "" && !outputFormat.IsStructured() && (fromSelector != "" || toSelector != "") && !merged {
+			return fmt.Errorf("il formato html con --from/--to richiede --merged")
 		}
 
-		hierarchy := organizer.NewReleaseHierarchy(issues, false)
+		var releases []changelogRelease
+		var from, to *jira.Version
+		if fromSelector != "" || toSelector != "" {
+			from, to, releases, err = collectReleaseRange(cmd, fromSelector, toSelector, merged, filter)
+			if err != nil {
+				return err
+			}
+		} else {
+			versionToFetch, err := resolveJiraVersion(cmd, jiraClient, projectKey)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "✅ Generazione changelog per la versione: %s\n", versionToFetch.Name)
+
+			issues, err := jira.GetIssuesForVersion(ctx, jiraClient, projectKey, versionToFetch.Name, filter)
+			if err != nil {
+				return fmt.Errorf("errore nel recupero dei ticket: %w", err)
+			}
+
+			releases = []changelogRelease{{version: versionToFetch, hierarchy: organizer.NewReleaseHierarchy(issues, false)}}
+		}
 
 		var changelog string
-		switch format {
-		case "markdown", "md":
-			changelog = templates.RenderMarkdown(versionToFetch, hierarchy, includeSubtasks, jiraClient.BaseURL)
-		case "teams":
-			changelog = templates.RenderTeams(versionToFetch, hierarchy, includeSubtasks, jiraClient.BaseURL)
+		switch {
+		case outputFormat.IsStructured():
+			var data interface{}
+			if from != nil {
+				releaseRange := output.ReleaseRange{From: output.NewVersion(*from), To: output.NewVersion(*to), Merged: merged}
+				for _, release := range releases {
+					releaseRange.Releases = append(releaseRange.Releases, output.NewRelease(release.version, release.hierarchy, jiraClient.BaseURL))
+				}
+				data = releaseRange
+			} else {
+				data = output.NewRelease(releases[0].version, releases[0].hierarchy, jiraClient.BaseURL)
+			}
+
+			var buf bytes.Buffer
+			if err := output.Write(&buf, outputFormat, data); err != nil {
+				return fmt.Errorf("errore nella serializzazione del changelog: %w", err)
+			}
+			changelog = buf.String()
+		case slack:
+			var messages []publish.SlackMessage
+			for _, release := range releases {
+				messages = append(messages, publish.NewSlackMessages(publish.NewRelease(release.version, release.hierarchy, includeSubtasks, jiraClient.BaseURL, projectKey))...)
+			}
+
+			payload, err := publish.MarshalIndent(messages)
+			if err != nil {
+				return fmt.Errorf("errore nella serializzazione del changelog: %w", err)
+			}
+			changelog = string(payload)
+		case keepAChangelog:
+			var sections []string
+			for _, release := range releases {
+				date := release.version.ReleaseDate
+				if date == "" {
+					date = keepachangelog.UnreleasedDate
+				}
+				issues := templates.AllIssues(release.hierarchy)
+				sortIssuesByKey(issues)
+				sections = append(sections, keepachangelog.Render(release.version.Name, date, issues, mapping, func(key string) string {
+					return fmt.Sprintf("%s/browse/%s", jiraClient.BaseURL, key)
+				}))
+			}
+
+			// Con --output il file esistente viene aggiornato in place invece che sovrascritto
+			if outputFile != "" {
+				return updateKeepAChangelog(outputFile, releases, sections)
+			}
+			changelog = strings.Join(sections, "\n")
 		default:
-			changelog = templates.RenderMarkdown(versionToFetch, hierarchy, includeSubtasks, jiraClient.BaseURL)
+			// Una sezione per versione, nello stesso formato del changelog di una singola versione
+			var sections []string
+			for _, release := range releases {
+				section, err := templates.Render(tmpl, templates.NewData(release.version, release.hierarchy, includeSubtasks, jiraClient.BaseURL))
+				if err != nil {
+					return err
+				}
+				sections = append(sections, section)
+			}
+			changelog = strings.Join(sections, "\n")
 		}
 
 		if outputFile != "" {
@@ -53,7 +183,9 @@ formattato in Markdown (o altri formati) basato sui ticket.`,
 			if err != nil {
 				return fmt.Errorf("errore nel salvataggio del file: %w", err)
 			}
-			fmt.Printf("✅ Changelog salvato in: %s\n", outputFile)
+			fmt.Fprintf(os.Stderr, "✅ Changelog salvato in: %s\n", outputFile)
+		} else if outputFormat.IsStructured() {
+			fmt.Print(changelog)
 		} else {
 			fmt.Println(changelog)
 		}
@@ -65,6 +197,102 @@ formattato in Markdown (o altri formati) basato sui ticket.`,
 func init() {
 	rootCmd.AddCommand(changelogCmd)
 	changelogCmd.Flags().StringP("output", "o", "", "File di output per salvare il changelog")
-	changelogCmd.Flags().StringP("format", "f", "markdown", "Formato del changelog: markdown, teams")
+	changelogCmd.Flags().StringP("format", "f", "markdown", "Formato del changelog: markdown, teams, slack, html, keepachangelog")
+	changelogCmd.Flags().StringP("template", "t", "", "File di template (text/template) personalizzato; ha la precedenza su --format")
 	changelogCmd.Flags().BoolP("include-subtasks", "s", false, "Includi i sub-task nel changelog")
+	changelogCmd.Flags().String("from", "", "Prima versione dell'intervallo (inclusa), stessa sintassi di --version; con un intervallo --status vale all se non indicato")
+	changelogCmd.Flags().String("to", "", "Ultima versione dell'intervallo (inclusa), stessa sintassi di --version")
+	changelogCmd.Flags().String("type-mapping", "", "Con --format keepachangelog, associa i tipi di issue alle sezioni (es. \"Story=Added,Bug=Fixed,Spike=-\")")
+	changelogCmd.Flags().Bool("merged", false, "Con --from/--to, unisce tutte le versioni in un unico changelog invece di una sezione per versione")
+	addVersionFlags(changelogCmd)
+	addIssueFilterFlags(changelogCmd, jira.StatusOpen)
+}
+
+// changelogRelease è una versione con la relativa gerarchia di ticket da renderizzare
+type changelogRelease struct {
+	version   *jira.Version
+	hierarchy *organizer.ReleaseHierarchy
+}
+
+// collectReleaseRange recupera i ticket di tutte le versioni tra from e to (incluse). I ticket con
+// più fixVersion compaiono una sola volta; con merged viene restituita un'unica release combinata.
+func collectReleaseRange(cmd *cobra.Command, fromSelector, toSelector string, merged bool, filter jira.IssueFilter) (*jira.Version, *jira.Version, []changelogRelease, error) {
+	ctx := cmd.Context()
+
+	if fromSelector == "" || toSelector == "" {
+		return nil, nil, nil, fmt.Errorf("i flag --from e --to vanno usati insieme")
+	}
+	for _, name := range []string{"version", "next", "latest-released"} {
+		if cmd.Flags().Changed(name) {
+			return nil, nil, nil, fmt.Errorf("il flag --%s non può essere usato con --from/--to", name)
+		}
+	}
+
+	versions, err := jira.GetAllProjectVersions(ctx, jiraClient, projectKey)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("errore nel recupero delle versioni: %w", err)
+	}
+	from, err := matchVersion(versions, fromSelector)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	to, err := matchVersion(versions, toSelector)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	inRange, err := jira.VersionsBetween(versions, from, to)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	from, to = &inRange[0], &inRange[len(inRange)-1]
+	fmt.Fprintf(os.Stderr, "✅ Generazione changelog dalla versione %s alla versione %s (%d versioni)\n", from.Name, to.Name, len(inRange))
+
+	issuesByVersion, err := jira.GetIssuesForVersions(ctx, jiraClient, projectKey, inRange, filter)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("errore nel recupero dei ticket: %w", err)
+	}
+
+	if merged {
+		var allIssues []jira.Issue
+		for _, issues := range issuesByVersion {
+			allIssues = append(allIssues, issues...)
+		}
+		combined := &jira.Version{
+			Name:        fmt.Sprintf("%s → %s", from.Name, to.Name),
+			ReleaseDate: to.ReleaseDate,
+			Description: fmt.Sprintf("Modifiche dalla versione %s alla versione %s", from.Name, to.Name),
+		}
+		return from, to, []changelogRelease{{version: combined, hierarchy: organizer.NewReleaseHierarchy(allIssues, false)}}, nil
+	}
+
+	releases := make([]changelogRelease, 0, len(inRange))
+	for i := range inRange {
+		releases = append(releases, changelogRelease{version: &inRange[i], hierarchy: organizer.NewReleaseHierarchy(issuesByVersion[i], false)})
+	}
+	return from, to, releases, nil
+}
+
+// updateKeepAChangelog inserisce o sostituisce le sezioni delle versioni in un CHANGELOG.md,
+// lasciando invariate le altre. Upsert mantiene le versioni in ordine, dalla più recente.
+func updateKeepAChangelog(path string, releases []changelogRelease, sections []string) error {
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("impossibile leggere %s: %w", path, err)
+	}
+
+	updated := string(content)
+	for i, release := range releases {
+		updated = keepachangelog.Upsert(updated, release.version.Name, sections[i])
+	}
+
+	if updated == string(content) {
+		fmt.Fprintf(os.Stderr, "✅ %s è già aggiornato\n", path)
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+		return fmt.Errorf("errore nel salvataggio del file: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "✅ Changelog aggiornato in: %s\n", path)
+	return nil
 }
@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"jira-release-manager/internal/jira"
+	"jira-release-manager/internal/output"
+	"jira-release-manager/internal/templates"
+
+	"github.com/spf13/cobra"
+)
+
+var commentCmd = &cobra.Command{
+	Use:   "comment",
+	Short: "Pubblica un commento di rilascio su tutti i ticket di una versione.",
+	Long: `Genera da un template (text/template) il commento di rilascio di ogni ticket della versione
+e lo pubblica: in ADF su Jira Cloud, come testo semplice su Server/Data Center. I ticket che hanno
+già un commento identico vengono ignorati, quindi il comando può essere rieseguito senza duplicati.
+
+Il template riceve gli stessi dati del changelog (.Version, .ReleaseDate, .BaseURL) più il ticket
+corrente (.Issue); le righe vuote separano i paragrafi. .ReleaseDate è la data di rilascio della
+versione o quella indicata con --date, obbligatoria per le versioni senza data. Il template predefinito è:
+  ` + templates.DefaultComment,
+	Example: `  jira-release-manager comment -p PROJ --next --date 2026-10-12 --dry-run
+  jira-release-manager comment -p PROJ --version 2.4.0 --text "Rilasciato in {{ .Version.Name }} il {{ .ReleaseDate }}" --yes
+  jira-release-manager comment -p PROJ --latest-released --template release-comment.tmpl --concurrency 4`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		text, _ := cmd.Flags().GetString("text")
+		templateFile, _ := cmd.Flags().GetString("template")
+		date, _ := cmd.Flags().GetString("date")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		yes, _ := cmd.Flags().GetBool("yes")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		if err := validateDates(date); err != nil {
+			return err
+		}
+		if concurrency < 0 {
+			return fmt.Errorf("--concurrency deve essere almeno 1")
+		}
+		if concurrency == 0 {
+			concurrency = jiraClient.Concurrency
+		}
+
+		var tmpl *templates.Template
+		var err error
+		if templateFile != "" {
+			tmpl, err = templates.FromFile(templateFile)
+		} else {
+			tmpl, err = templates.ParseComment(text)
+		}
+		if err != nil {
+			return err
+		}
+
+		filter, err := issueFilterFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		version, err := resolveJiraVersion(cmd, jiraClient, projectKey)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "✅ Versione selezionata: %s\n", version.Name)
+
+		// Senza una data fissa il commento cambierebbe ogni giorno e una nuova esecuzione
+		// non lo riconoscerebbe come già pubblicato
+		data := templates.NewData(version, nil, false, jiraClient.BaseURL)
+		switch {
+		case date != "":
+			data.ReleaseDate = date
+		case version.ReleaseDate == "":
+			return fmt.Errorf("la versione %s non ha una data di rilascio: indica la data del commento con --date", version.Name)
+		}
+
+		issues, err := jira.GetIssuesForVersion(ctx, jiraClient, projectKey, version.Name, filter)
+		if err != nil {
+			return fmt.Errorf("errore nel recupero dei ticket: %w", err)
+		}
+		sortIssuesByKey(issues)
+
+		plans := make([]commentPlan, 0, len(issues))
+		for _, issue := range issues {
+			rendered, err := templates.RenderComment(tmpl, templates.CommentData{Data: data, Issue: issue})
+			if err != nil {
+				return err
+			}
+			// Il testo viene confrontato con i commenti esistenti nella forma in cui Jira lo salva
+			rendered = jira.NormalizeText(rendered)
+			if rendered == "" {
+				return fmt.Errorf("il template produce un commento vuoto per %s", issue.Key)
+			}
+			plans = append(plans, commentPlan{issue: issue, text: rendered})
+		}
+
+		fmt.Fprintf(os.Stderr, "⏳ Verifica dei commenti esistenti (%d ticket)...", len(plans))
+		jira.ForEachConcurrent(len(plans), concurrency, func(i int) {
+			plan := &plans[i]
+			exists, err := jira.HasComment(ctx, jiraClient, plan.issue.Key, plan.text)
+			switch {
+			case err != nil:
+				plan.outcome, plan.err = "failed", err
+			case exists:
+				plan.outcome = "exists"
+			default:
+				plan.outcome = "planned"
+			}
+		})
+		if err := ctx.Err(); err != nil {
+			fmt.Fprintln(os.Stderr, " ❌")
+			return fmt.Errorf("verifica dei commenti interrotta: %w", err)
+		}
+		fmt.Fprintln(os.Stderr, " ✓")
+		fmt.Fprintln(os.Stderr)
+
+		planned := 0
+		for _, plan := range plans {
+			if plan.outcome == "planned" {
+				planned++
+			}
+		}
+
+		if err := printCommentPlans(plans); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr)
+
+		writeResults := func() error {
+			if !outputFormat.IsStructured() {
+				return nil
+			}
+			results := make([]output.CommentResult, 0, len(plans))
+			for _, plan := range plans {
+				results = append(results, plan.result(jiraClient.BaseURL))
+			}
+			return output.Write(os.Stdout, outputFormat, results)
+		}
+		// failures conta i ticket non riusciti, sia nella verifica sia nella pubblicazione
+		failures := func() error {
+			failed := 0
+			for _, plan := range plans {
+				if plan.outcome == "failed" {
+					failed++
+				}
+			}
+			if failed == 0 {
+				return nil
+			}
+			cmd.SilenceUsage = true
+			return fmt.Errorf("commento non pubblicato su %d ticket su %d", failed, len(plans))
+		}
+
+		if planned == 0 {
+			fmt.Fprintln(os.Stderr, "ℹ️  Nessun commento da pubblicare.")
+			if err := writeResults(); err != nil {
+				return err
+			}
+			return failures()
+		}
+		if dryRun {
+			fmt.Fprintln(os.Stderr, "ℹ️  Modalità dry-run: nessun commento pubblicato.")
+			if err := writeResults(); err != nil {
+				return err
+			}
+			return failures()
+		}
+
+		if !yes {
+			confirmed, err := confirm(fmt.Sprintf("Pubblicare il commento su %d ticket?", planned))
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Fprintln(os.Stderr, "ℹ️  Nessun commento pubblicato.")
+				return writeResults()
+			}
+		}
+
+		fmt.Fprintf(os.Stderr, "⏳ Pubblicazione dei commenti (%d ticket)...", planned)
+		jira.ForEachConcurrent(len(plans), concurrency, func(i int) {
+			plan := &plans[i]
+			if plan.outcome != "planned" {
+				return
+			}
+			if err := jira.AddComment(ctx, jiraClient, plan.issue.Key, plan.text); err != nil {
+				plan.outcome, plan.err = "failed", err
+				return
+			}
+			plan.outcome = "posted"
+		})
+		if err := ctx.Err(); err != nil {
+			fmt.Fprintln(os.Stderr, " ❌")
+			return fmt.Errorf("pubblicazione dei commenti interrotta: %w", err)
+		}
+		fmt.Fprintln(os.Stderr, " ✓")
+
+		posted := 0
+		for _, plan := range plans {
+			switch plan.outcome {
+			case "posted":
+				posted++
+			case "failed":
+				fmt.Fprintf(os.Stderr, "❌ %v\n", plan.err)
+			}
+		}
+
+		if err := writeResults(); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "✅ Commento pubblicato su %d ticket\n", posted)
+		return failures()
+	},
+}
+
+// commentPlan è il commento di rilascio previsto per un ticket e il suo esito
+type commentPlan struct {
+	issue   jira.Issue
+	text    string
+	outcome string // planned, posted, exists, failed
+	err     error
+}
+
+// commentOutcomes sono le etichette con cui viene mostrato l'esito di un commento
+var commentOutcomes = map[string]string{
+	"planned": "💬 da pubblicare",
+	"posted":  "✅ pubblicato",
+	"exists":  "✅ già presente",
+	"failed":  "❌ errore",
+}
+
+// result converte il piano nello schema di output
+func (p commentPlan) result(baseURL string) output.CommentResult {
+	result := output.CommentResult{
+		Key:     p.issue.Key,
+		Summary: p.issue.Fields.Summary,
+		URL:     fmt.Sprintf("%s/browse/%s", baseURL, p.issue.Key),
+		Comment: p.text,
+		Outcome: p.outcome,
+	}
+	if p.err != nil {
+		result.Error = p.err.Error()
+	}
+	return result
+}
+
+// printCommentPlans stampa su stderr l'anteprima dei commenti: il testo del primo da pubblicare
+// e l'esito previsto per ogni ticket
+func printCommentPlans(plans []commentPlan) error {
+	for _, plan := range plans {
+		if plan.outcome != "planned" {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "💬 Anteprima del commento (%s):\n\n", plan.issue.Key)
+		for _, line := range strings.Split(plan.text, "\n") {
+			fmt.Fprintf(os.Stderr, "   %s\n", line)
+		}
+		fmt.Fprintln(os.Stderr)
+		break
+	}
+
+	w := tabwriter.NewWriter(os.Stderr, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TICKET\tSOMMARIO\tESITO")
+	for _, plan := range plans {
+		outcome := commentOutcomes[plan.outcome]
+		if plan.err != nil {
+			outcome += ": " + plan.err.Error()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", plan.issue.Key, truncateText(plan.issue.Fields.Summary, 50), outcome)
+	}
+	return w.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(commentCmd)
+	commentCmd.Flags().String("text", templates.DefaultComment, "Template (text/template) del commento")
+	commentCmd.Flags().StringP("template", "t", "", "File di template del commento; ha la precedenza su --text")
+	commentCmd.Flags().String("date", "", "Data di rilascio usata nel commento (YYYY-MM-DD); obbligatoria se la versione non ne ha una")
+	commentCmd.Flags().Int("concurrency", 0, "Richieste contemporanee verso Jira (default: JIRA_CONCURRENCY)")
+	commentCmd.Flags().BoolP("yes", "y", false, "Pubblica i commenti senza chiedere conferma")
+	commentCmd.Flags().Bool("dry-run", false, "Mostra i commenti previsti senza pubblicarli")
+	addVersionFlags(commentCmd)
+	addIssueFilterFlags(commentCmd, jira.StatusAll)
+}
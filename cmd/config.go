@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"jira-release-manager/internal/config"
+	"jira-release-manager/internal/keepachangelog"
+	"jira-release-manager/internal/output"
+	"jira-release-manager/internal/readiness"
+	"jira-release-manager/internal/templates"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// settingKeys sono le impostazioni mostrate da config show, nell'ordine in cui compaiono
+var settingKeys = []string{
+	"JIRA_URL", "JIRA_DEPLOYMENT", "JIRA_AUTH_TYPE", "JIRA_USERNAME", "JIRA_API_TOKEN", "JIRA_CREDENTIAL_STORE",
+	"DEFAULT_PROJECT",
+	"JIRA_EPIC_LINK_FIELD", "JIRA_SUBTASK_TYPES", "JIRA_TIMEOUT", "JIRA_MAX_RETRIES", "JIRA_CONCURRENCY",
+	"CHANGELOG_TYPE_MAPPING", "TEAMS_WEBHOOK_URL", "SLACK_WEBHOOK_URL", "CONFLUENCE_URL",
+	"CONFLUENCE_JIRA_SERVER_ID", "GITHUB_API_URL", "GITLAB_API_URL",
+}
+
+// secretKeys sono le impostazioni il cui valore non viene mai mostrato per intero
+var secretKeys = map[string]bool{
+	"JIRA_API_TOKEN":    true,
+	"TEAMS_WEBHOOK_URL": true,
+	"SLACK_WEBHOOK_URL": true,
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Gestisce il file di configurazione con i profili.",
+	Long: `Il file di configurazione (default: $XDG_CONFIG_HOME/jira-release-manager/config.yaml,
+o ~/.config/jira-release-manager/config.yaml) contiene uno o più profili, ciascuno con
+URL, autenticazione, progetto predefinito, campi personalizzati e valori predefiniti dei renderer.
+Il profilo si sceglie con --profile (o JIRA_PROFILE); altrimenti si usa default_profile.`,
+	Annotations: map[string]string{annotationNoJiraClient: "true"},
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Crea il file di configurazione o vi aggiunge un profilo.",
+	Long: `Crea un profilo a partire dalla configurazione corrente (variabili d'ambiente, .env e flag)
+e lo salva nel file di configurazione. Il token API non viene mai scritto nel file.`,
+	Example: `  jira-release-manager config init -p PROJ
+  jira-release-manager config init --profile datacenter
+  jira-release-manager config init --profile cloud --force`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		force, _ := cmd.Flags().GetBool("force")
+		project, _ := cmd.Flags().GetString("project")
+
+		file := configFile
+		if file == nil {
+			if configErr != nil && !errors.Is(configErr, fs.ErrNotExist) {
+				return configErr
+			}
+			file = &config.File{Profiles: make(map[string]config.Profile)}
+		}
+
+		name, _ := cmd.Flags().GetString("profile")
+		if name == "" {
+			name = "default"
+		}
+		if _, exists := file.Profiles[name]; exists && !force {
+			return fmt.Errorf("il profilo '%s' esiste già in %s: usa --force per sovrascriverlo", name, configPath)
+		}
+		if project == "" {
+			project = viper.GetString("DEFAULT_PROJECT")
+		}
+
+		profile := config.Profile{
+			URL:        viper.GetString("JIRA_URL"),
+			Deployment: viper.GetString("JIRA_DEPLOYMENT"),
+			AuthType:   viper.GetString("JIRA_AUTH_TYPE"),
+			Username:   viper.GetString("JIRA_USERNAME"),
+			Project:    project,
+			Fields:     config.Fields{EpicLink: viper.GetString("JIRA_EPIC_LINK_FIELD")},
+			Timeout:    viper.GetString("JIRA_TIMEOUT"),
+		}
+		if value := viper.GetString("JIRA_SUBTASK_TYPES"); value != "" {
+			profile.SubtaskTypes = strings.Split(value, ",")
+		}
+		if profile.URL == "" {
+			profile.URL = "https://your-domain.atlassian.net"
+		}
+
+		file.Profiles[name] = profile
+		if file.DefaultProfile == "" {
+			file.DefaultProfile = name
+		}
+		if err := file.Save(configPath); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "✅ Profilo '%s' salvato in %s\n", name, configPath)
+		fmt.Fprintln(os.Stderr, "💡 Il token API non è stato salvato: impostalo con la variabile JIRA_API_TOKEN.")
+		return nil
+	},
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Mostra la configurazione effettiva e la sua provenienza.",
+	Example: `  jira-release-manager config show
+  jira-release-manager config show --profile datacenter --output-format yaml`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if configErr != nil {
+			return configErr
+		}
+
+		settings := make(map[string]string)
+		sources := make(map[string]string)
+		var profileSettings map[string]string
+		if activeProfile != nil {
+			profileSettings = activeProfile.Settings()
+		}
+		for _, key := range settingKeys {
+			value := viper.GetString(key)
+			if value == "" {
+				continue
+			}
+			if secretKeys[key] {
+				value = maskSecret(value)
+			}
+			settings[key] = value
+
+			_, fromEnv := os.LookupEnv(key)
+			_, fromProfile := profileSettings[key]
+			switch {
+			case fromEnv:
+				sources[key] = "ambiente"
+			case fromProfile:
+				sources[key] = "profilo " + profileName
+			default:
+				sources[key] = ".env"
+			}
+		}
+
+		if outputFormat.IsStructured() {
+			return output.Write(os.Stdout, outputFormat, map[string]interface{}{
+				"configFile": configPath,
+				"profile":    profileName,
+				"settings":   settings,
+			})
+		}
+
+		if configFile != nil {
+			fmt.Printf("File di configurazione: %s\n", configPath)
+		} else {
+			fmt.Printf("File di configurazione: %s (non presente)\n", configPath)
+		}
+		if profileName != "" {
+			fmt.Printf("Profilo attivo: %s\n\n", profileName)
+		} else {
+			fmt.Printf("Profilo attivo: nessuno\n\n")
+		}
+
+		if len(settings) == 0 {
+			fmt.Println("Nessuna impostazione configurata.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "IMPOSTAZIONE\tVALORE\tORIGINE")
+		fmt.Fprintln(w, "------------\t------\t-------")
+		for _, key := range settingKeys {
+			if value, ok := settings[key]; ok {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", key, value, sources[key])
+			}
+		}
+		return w.Flush()
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Verifica il file di configurazione e tutti i suoi profili.",
+	Example: `  jira-release-manager config validate
+  jira-release-manager config validate --config ./ci-config.yaml`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := config.Load(configPath)
+		if err != nil {
+			return err
+		}
+		if len(file.Profiles) == 0 {
+			return fmt.Errorf("nessun profilo definito in %s", configPath)
+		}
+		if file.DefaultProfile != "" {
+			if _, ok := file.Profiles[file.DefaultProfile]; !ok {
+				return fmt.Errorf("default_profile '%s' non corrisponde a nessun profilo", file.DefaultProfile)
+			}
+		}
+
+		fmt.Printf("🔎 Verifica di %s\n\n", configPath)
+		invalid := 0
+		for _, name := range file.ProfileNames() {
+			profile := file.Profiles[name]
+			problems := profile.Validate()
+			if format := profile.Changelog.Format; format != "" && format != "slack" && format != "keepachangelog" {
+				if _, err := templates.Builtin(format); err != nil {
+					problems = append(problems, err)
+				}
+			}
+			if mapping := profile.Changelog.TypeMapping; mapping != "" {
+				if _, err := keepachangelog.ParseMapping(mapping); err != nil {
+					problems = append(problems, err)
+				}
+			}
+			if rules := profile.Readiness.Rules; rules != "" {
+				if _, err := readiness.ParseLevels(rules); err != nil {
+					problems = append(problems, err)
+				}
+			}
+
+			if len(problems) == 0 {
+				fmt.Printf("✅ %s\n", name)
+			} else {
+				invalid++
+				fmt.Printf("❌ %s\n", name)
+				for _, problem := range problems {
+					fmt.Printf("   - %s\n", problem)
+				}
+			}
+			// Senza interrogare Jira si possono riconoscere solo gli schemi predefiniti: le altre
+			// priorità vengono verificate da readiness sulle priorità del server
+			for _, priority := range []string{profile.Readiness.HighPriority, profile.Readiness.MaxBugPriority} {
+				if priority == "" {
+					continue
+				}
+				if err := readiness.ValidatePriority(priority, nil); err != nil {
+					fmt.Printf("⚠️  %s: la priorità %q non appartiene agli schemi predefiniti, verrà verificata su Jira\n", name, priority)
+				}
+			}
+			if profile.APIToken != "" {
+				fmt.Printf("⚠️  %s: api_token è salvato in chiaro nel file, preferisci la variabile JIRA_API_TOKEN\n", name)
+			}
+		}
+		fmt.Println()
+
+		if invalid > 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%d profili su %d non sono validi", invalid, len(file.Profiles))
+		}
+		fmt.Printf("✅ Configurazione valida (%d profili)\n", len(file.Profiles))
+		return nil
+	},
+}
+
+// maskSecret nasconde un segreto lasciandone visibili solo gli ultimi caratteri
+func maskSecret(value string) string {
+	if len(value) <= 8 {
+		return strings.Repeat("*", len(value))
+	}
+	return strings.Repeat("*", 8) + value[len(value)-4:]
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configInitCmd, configShowCmd, configValidateCmd)
+	configInitCmd.Flags().Bool("force", false, "Sovrascrive il profilo se esiste già")
+}
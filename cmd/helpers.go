@@ -1,18 +1,144 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path"
+	"regexp"
+	"strings"
 
 	"jira-release-manager/internal/jira"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
+// addVersionFlags registra i flag per la selezione non interattiva della versione.
+func addVersionFlags(cmd *cobra.Command) {
+	cmd.Flags().String("version", "", "Versione da usare: nome esatto, glob (es. 2.4.*), id:<ID> oppure re:<regex>")
+	cmd.Flags().Bool("next", false, "Usa la prossima versione non rilasciata")
+	cmd.Flags().Bool("latest-released", false, "Usa l'ultima versione rilasciata")
+}
+
+// addIssueFilterFlags registra i flag per filtrare i ticket di una versione.
+func addIssueFilterFlags(cmd *cobra.Command, defaultStatus jira.StatusFilter) {
+	cmd.Flags().String("status", string(defaultStatus), "Ticket da includere in base allo stato: open, done, all")
+	cmd.Flags().String("jql-filter", "", "Clausole JQL aggiuntive da applicare alla ricerca (es. \"component = API\")")
+}
+
+// issueFilterFromFlags costruisce il filtro dei ticket a partire dai flag del comando.
+func issueFilterFromFlags(cmd *cobra.Command) (jira.IssueFilter, error) {
+	status, _ := cmd.Flags().GetString("status")
+	jql, _ := cmd.Flags().GetString("jql-filter")
+
+	statusFilter, err := jira.ParseStatusFilter(status)
+	if err != nil {
+		return jira.IssueFilter{}, err
+	}
+
+	return jira.IssueFilter{Status: statusFilter, JQL: jql}, nil
+}
+
+// resolveJiraVersion determina la versione da usare a partire dai flag del comando.
+// Se nessun selettore è specificato ricade sul prompt interattivo, ma solo se stdin è un terminale.
+func resolveJiraVersion(cmd *cobra.Command, client *jira.Client, projectKey string) (*jira.Version, error) {
+	ctx := cmd.Context()
+	selector, _ := cmd.Flags().GetString("version")
+	next, _ := cmd.Flags().GetBool("next")
+	latestReleased, _ := cmd.Flags().GetBool("latest-released")
+
+	selectors := 0
+	for _, set := range []bool{selector != "", next, latestReleased} {
+		if set {
+			selectors++
+		}
+	}
+	if selectors > 1 {
+		return nil, fmt.Errorf("i flag --version, --next e --latest-released sono mutuamente esclusivi")
+	}
+
+	switch {
+	case next:
+		return jira.FindNextReleaseVersion(ctx, client, projectKey)
+	case latestReleased:
+		return jira.FindLatestReleasedVersion(ctx, client, projectKey)
+	case selector != "":
+		versions, err := jira.GetAllProjectVersions(ctx, client, projectKey)
+		if err != nil {
+			return nil, fmt.Errorf("errore nel recupero delle versioni: %w", err)
+		}
+		return matchVersion(versions, selector)
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return nil, fmt.Errorf("nessuna versione specificata: usa --version, --next o --latest-released in modalità non interattiva")
+	}
+	return selectJiraVersion(ctx, client, projectKey)
+}
+
+// matchVersion cerca una versione tramite selettore. In caso di più corrispondenze (glob o regex)
+// restituisce quella con la data di rilascio più recente, ignorando le versioni senza data;
+// se nessuna ha una data il selettore è ambiguo.
+func matchVersion(versions []jira.Version, selector string) (*jira.Version, error) {
+	var match func(v jira.Version) bool
+
+	switch {
+	case strings.HasPrefix(selector, "id:"):
+		id := strings.TrimPrefix(selector, "id:")
+		match = func(v jira.Version) bool { return v.ID == id }
+	case strings.HasPrefix(selector, "re:"):
+		re, err := regexp.Compile(strings.TrimPrefix(selector, "re:"))
+		if err != nil {
+			return nil, fmt.Errorf("espressione regolare non valida %q: %w", selector, err)
+		}
+		match = func(v jira.Version) bool { return re.MatchString(v.Name) }
+	case strings.ContainsAny(selector, "*?["):
+		if _, err := path.Match(selector, ""); err != nil {
+			return nil, fmt.Errorf("pattern glob non valido %q: %w", selector, err)
+		}
+		match = func(v jira.Version) bool {
+			ok, _ := path.Match(selector, v.Name)
+			return ok
+		}
+	default:
+		// Nome esatto, con fallback sull'ID
+		for i := range versions {
+			if versions[i].Name == selector {
+				return &versions[i], nil
+			}
+		}
+		match = func(v jira.Version) bool { return v.ID == selector }
+	}
+
+	var matches []jira.Version
+	for _, v := range versions {
+		if match(v) {
+			matches = append(matches, v)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("nessuna versione corrisponde a %q", selector)
+	case 1:
+		return &matches[0], nil
+	}
+	if latest := jira.LatestDated(matches); latest != nil {
+		return latest, nil
+	}
+	names := make([]string, len(matches))
+	for i, v := range matches {
+		names[i] = v.Name
+	}
+	return nil, fmt.Errorf("%q corrisponde a più versioni senza data di rilascio (%s): indica il nome esatto", selector, strings.Join(names, ", "))
+}
+
 // selectJiraVersion mostra un prompt interattivo per selezionare una versione.
-func selectJiraVersion(client *jira.Client, projectKey string) (*jira.Version, error) {
-	fmt.Printf("🔎 Ricerca versioni per il progetto %s...\n", projectKey)
-	versions, err := jira.GetAllProjectVersions(client, projectKey)
+func selectJiraVersion(ctx context.Context, client *jira.Client, projectKey string) (*jira.Version, error) {
+	fmt.Fprintf(os.Stderr, "🔎 Ricerca versioni per il progetto %s...\n", projectKey)
+	versions, err := jira.GetAllProjectVersions(ctx, client, projectKey)
 	if err != nil {
 		return nil, fmt.Errorf("errore nel recupero delle versioni: %w", err)
 	}
@@ -59,6 +185,30 @@ func selectJiraVersion(client *jira.Client, projectKey string) (*jira.Version, e
 	}
 
 	selectedVersion := optionMap[selectedOption]
-	fmt.Println()
+	fmt.Fprintln(os.Stderr)
 	return &selectedVersion, nil
 }
+
+// confirm chiede conferma prima di un'operazione che modifica i dati su Jira.
+// Senza terminale non è possibile chiedere conferma: l'operazione va autorizzata con --yes.
+func confirm(message string) (bool, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false, fmt.Errorf("impossibile chiedere conferma in modalità non interattiva: usa --yes")
+	}
+
+	confirmed := false
+	prompt := &survey.Confirm{Message: message}
+	if err := survey.AskOne(prompt, &confirmed, survey.WithStdio(os.Stdin, os.Stderr, os.Stderr)); err != nil {
+		return false, fmt.Errorf("conferma annullata o fallita: %w", err)
+	}
+	return confirmed, nil
+}
+
+// truncateText accorcia un testo alla lunghezza indicata (in caratteri), aggiungendo "…"
+func truncateText(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-1]) + "…"
+}
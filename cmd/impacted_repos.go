@@ -2,10 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"sort"
 	"strings"
 
 	"jira-release-manager/internal/jira"
+	"jira-release-manager/internal/output"
 
 	"github.com/spf13/cobra"
 )
@@ -16,22 +18,31 @@ var impactedReposCmd = &cobra.Command{
 	Long: `Permette di selezionare interattivamente una versione e
 mostra tutti i ticket raggruppati per etichetta (repository).
 I ticket senza etichetta vengono ignorati.`,
-	Example: `  jira-release-manager impacted-repos -p PROJ`,
+	Example: `  jira-release-manager impacted-repos -p PROJ
+  jira-release-manager impacted-repos -p PROJ --next
+  jira-release-manager impacted-repos -p PROJ --next --output-format yaml`,
 
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Selettore interattivo
-		versionToUse, err := selectJiraVersion(jiraClient, projectKey)
+		ctx := cmd.Context()
+
+		filter, err := issueFilterFromFlags(cmd)
 		if err != nil {
 			return err
 		}
-		fmt.Printf("✅ Analisi repository per la versione: %s\n", versionToUse.Name)
 
-		issues, err := jira.GetIssuesForVersion(jiraClient, projectKey, versionToUse.Name)
+		// Selezione della versione (flag o prompt interattivo)
+		versionToUse, err := resolveJiraVersion(cmd, jiraClient, projectKey)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "✅ Analisi repository per la versione: %s\n", versionToUse.Name)
+
+		issues, err := jira.GetIssuesForVersion(ctx, jiraClient, projectKey, versionToUse.Name, filter)
 		if err != nil {
 			return fmt.Errorf("errore nel recupero dei ticket: %w", err)
 		}
 
-		if len(issues) == 0 {
+		if len(issues) == 0 && !outputFormat.IsStructured() {
 			fmt.Println("⚠️  Nessun ticket trovato per questa versione.")
 			return nil
 		}
@@ -47,6 +58,10 @@ I ticket senza etichetta vengono ignorati.`,
 			}
 		}
 
+		if outputFormat.IsStructured() {
+			return output.Write(os.Stdout, outputFormat, output.NewImpactedRepos(versionToUse, labelsToIssues, jiraClient.BaseURL))
+		}
+
 		if len(labelsToIssues) == 0 {
 			fmt.Println("ℹ️ Nessun ticket con etichette trovato per questa release.")
 			return nil
@@ -77,4 +92,6 @@ I ticket senza etichetta vengono ignorati.`,
 
 func init() {
 	rootCmd.AddCommand(impactedReposCmd)
+	addVersionFlags(impactedReposCmd)
+	addIssueFilterFlags(impactedReposCmd, jira.StatusOpen)
 }
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"jira-release-manager/internal/jira"
+	"jira-release-manager/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var issuesCmd = &cobra.Command{
+	Use:   "issues",
+	Short: "Modifica in blocco i ticket del progetto.",
+	Long: `Raccoglie i comandi che modificano più ticket alla volta. I ticket sono selezionati
+con una query JQL, limitata sempre al progetto indicato con --project.`,
+}
+
+var issuesSetVersionCmd = &cobra.Command{
+	Use:   "set-version",
+	Short: "Aggiunge o rimuove fixVersion sui ticket selezionati con una JQL.",
+	Long: `Risolve la JQL, mostra l'anteprima delle modifiche e, dopo conferma, aggiorna le fixVersion
+di ogni ticket con l'operazione update di Jira: le altre versioni già assegnate restano invariate.
+I ticket che hanno già le versioni richieste vengono ignorati.`,
+	Example: `  jira-release-manager issues set-version -p PROJ --jql "fixVersion = 2.3.0 AND statusCategory != Done" --add 2.4.0 --remove 2.3.0
+  jira-release-manager issues set-version -p PROJ --jql "key in (PROJ-12, PROJ-15)" --add 2.4.0 --yes
+  jira-release-manager issues set-version -p PROJ --jql "labels = api" --remove 2.4.0 --dry-run --output-format json`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		jql, _ := cmd.Flags().GetString("jql")
+		add, _ := cmd.Flags().GetStringSlice("add")
+		remove, _ := cmd.Flags().GetStringSlice("remove")
+		yes, _ := cmd.Flags().GetBool("yes")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		if len(add) == 0 && len(remove) == 0 {
+			return fmt.Errorf("indica almeno una versione con --add o --remove")
+		}
+		for _, name := range add {
+			if slices.Contains(remove, name) {
+				return fmt.Errorf("la versione %s è indicata sia in --add sia in --remove", name)
+			}
+		}
+
+		versions, err := jira.GetAllProjectVersions(ctx, jiraClient, projectKey)
+		if err != nil {
+			return fmt.Errorf("errore nel recupero delle versioni: %w", err)
+		}
+		for _, name := range slices.Concat(add, remove) {
+			if !slices.ContainsFunc(versions, func(v jira.Version) bool { return v.Name == name }) {
+				return fmt.Errorf("versione %s non trovata nel progetto %s", name, projectKey)
+			}
+		}
+
+		// La query viene limitata al progetto; l'eventuale ORDER BY va spostato fuori dalle parentesi
+		where, orderBy := jira.SplitOrderBy(jql)
+		if where == "" {
+			return fmt.Errorf("la JQL deve contenere almeno una condizione oltre a ORDER BY")
+		}
+		query := strings.TrimSpace(fmt.Sprintf(`project = "%s" AND (%s) %s`, projectKey, where, orderBy))
+
+		fmt.Fprint(os.Stderr, "⏳ Ricerca dei ticket...")
+		issues, err := jira.SearchIssues(ctx, jiraClient, query, "summary,status,issuetype,fixVersions")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, " ❌")
+			return fmt.Errorf("errore nella ricerca JQL: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, " ✓ (%d trovati)\n\n", len(issues))
+
+		// Per ogni ticket si applica solo ciò che manca: versioni da aggiungere non ancora presenti
+		// e versioni da rimuovere effettivamente assegnate
+		var updates, unchanged []jira.FixVersionUpdate
+		for _, issue := range issues {
+			current := make([]string, 0, len(issue.Fields.FixVersions))
+			for _, version := range issue.Fields.FixVersions {
+				current = append(current, version.Name)
+			}
+
+			update := jira.FixVersionUpdate{Issue: issue}
+			for _, name := range add {
+				if !slices.Contains(current, name) {
+					update.Add = append(update.Add, name)
+				}
+			}
+			for _, name := range remove {
+				if slices.Contains(current, name) {
+					update.Remove = append(update.Remove, name)
+				}
+			}
+
+			if update.IsEmpty() {
+				unchanged = append(unchanged, update)
+			} else {
+				updates = append(updates, update)
+			}
+		}
+
+		results := make([]output.FixVersionResult, 0, len(issues))
+		for _, update := range unchanged {
+			results = append(results, output.NewFixVersionResult(update, "unchanged", nil, jiraClient.BaseURL))
+		}
+
+		if len(updates) == 0 {
+			fmt.Fprintf(os.Stderr, "ℹ️  Nessun ticket da modificare (%d già aggiornati).\n", len(unchanged))
+			if outputFormat.IsStructured() {
+				return output.Write(os.Stdout, outputFormat, results)
+			}
+			return nil
+		}
+
+		if err := printFixVersionUpdates(updates); err != nil {
+			return err
+		}
+		if len(unchanged) > 0 {
+			fmt.Fprintf(os.Stderr, "ℹ️  %d ticket hanno già le versioni richieste e verranno ignorati.\n", len(unchanged))
+		}
+		fmt.Fprintln(os.Stderr)
+
+		if dryRun {
+			fmt.Fprintln(os.Stderr, "ℹ️  Modalità dry-run: nessuna modifica applicata.")
+			if outputFormat.IsStructured() {
+				for _, update := range updates {
+					results = append(results, output.NewFixVersionResult(update, "planned", nil, jiraClient.BaseURL))
+				}
+				return output.Write(os.Stdout, outputFormat, results)
+			}
+			return nil
+		}
+
+		if !yes {
+			confirmed, err := confirm(fmt.Sprintf("Aggiornare le fixVersion di %d ticket?", len(updates)))
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Fprintln(os.Stderr, "ℹ️  Nessuna modifica applicata.")
+				return nil
+			}
+		}
+
+		errs, err := applyFixVersionUpdates(ctx, updates)
+		if err != nil {
+			return err
+		}
+
+		failed := 0
+		for i, update := range updates {
+			outcome := "updated"
+			if errs[i] != nil {
+				outcome = "failed"
+				failed++
+			}
+			results = append(results, output.NewFixVersionResult(update, outcome, errs[i], jiraClient.BaseURL))
+		}
+		if outputFormat.IsStructured() {
+			if err := output.Write(os.Stdout, outputFormat, results); err != nil {
+				return err
+			}
+		}
+
+		if failed > 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%d ticket su %d non aggiornati", failed, len(updates))
+		}
+		fmt.Fprintf(os.Stderr, "✅ FixVersion aggiornate su %d ticket\n", len(updates))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(issuesCmd)
+	issuesCmd.AddCommand(issuesSetVersionCmd)
+	issuesSetVersionCmd.Flags().String("jql", "", "Query JQL che seleziona i ticket, limitata al progetto; un ORDER BY finale è ammesso e viene applicato all'intera query")
+	issuesSetVersionCmd.Flags().StringSlice("add", nil, "Versioni da aggiungere (separate da virgola o ripetendo il flag)")
+	issuesSetVersionCmd.Flags().StringSlice("remove", nil, "Versioni da rimuovere (separate da virgola o ripetendo il flag)")
+	issuesSetVersionCmd.Flags().BoolP("yes", "y", false, "Applica le modifiche senza chiedere conferma")
+	issuesSetVersionCmd.Flags().Bool("dry-run", false, "Mostra l'anteprima delle modifiche senza applicarle")
+	_ = issuesSetVersionCmd.MarkFlagRequired("jql")
+}
@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"jira-release-manager/internal/jira"
+	"jira-release-manager/internal/lint"
+	"jira-release-manager/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Segnala le incongruenze di fixVersion nella gerarchia di una versione.",
+	Long: `Controlla la struttura dei ticket di una versione e segnala:
+  subtask-version     sub-task con fixVersion diverse da quelle del genitore
+  epic-child-version  figli di un epic della versione senza la fixVersion
+  multiple-versions   ticket con più fixVersion
+  missing-labels      ticket con la fixVersion ma senza etichette (esclusi gli epic)
+  open-epic           epic aperti con tutti i figli completati
+
+Con --fix vengono proposte le correzioni delle fixVersion (il sub-task si allinea al genitore,
+il figlio di un epic senza fixVersion riceve quella della versione), applicate dopo conferma.`,
+	Example: `  jira-release-manager lint -p PROJ --next
+  jira-release-manager lint -p PROJ --version 2.4.0 --fix
+  jira-release-manager lint -p PROJ --next --fix --yes
+  jira-release-manager lint -p PROJ --next --strict --output-format json`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		fix, _ := cmd.Flags().GetBool("fix")
+		yes, _ := cmd.Flags().GetBool("yes")
+		strict, _ := cmd.Flags().GetBool("strict")
+
+		filter, err := issueFilterFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		version, err := resolveJiraVersion(cmd, jiraClient, projectKey)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "🔎 Controllo della versione: %s\n", version.Name)
+
+		issues, err := jira.GetIssuesForVersion(ctx, jiraClient, projectKey, version.Name, filter)
+		if err != nil {
+			return fmt.Errorf("errore nel recupero dei ticket: %w", err)
+		}
+
+		parents, err := fetchMissingParents(ctx, issues)
+		if err != nil {
+			return err
+		}
+
+		problems := lint.Run(version.Name, issues, parents, jiraClient.SubtaskTypes)
+
+		if outputFormat.IsStructured() {
+			if err := output.Write(os.Stdout, outputFormat, output.NewLint(version, len(issues), problems, jiraClient.BaseURL)); err != nil {
+				return err
+			}
+		} else {
+			printLint(version, len(issues), problems)
+		}
+
+		if fix {
+			if err := applyLintFixes(ctx, lint.Fixes(problems), yes); err != nil {
+				return err
+			}
+		}
+
+		if strict && len(problems) > 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("trovati %d problemi nella versione %s", len(problems), version.Name)
+		}
+		return nil
+	},
+}
+
+// fetchMissingParents recupera i genitori dei sub-task che non fanno parte della versione,
+// necessari per confrontarne le fixVersion.
+func fetchMissingParents(ctx context.Context, issues []jira.Issue) (map[string]jira.Issue, error) {
+	inVersion := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		inVersion[issue.Key] = true
+	}
+
+	var keys []string
+	requested := make(map[string]bool)
+	for _, issue := range issues {
+		if !lint.IsSubtask(issue, jiraClient.SubtaskTypes) || issue.Fields.Parent == nil || len(issue.Fields.FixVersions) == 0 {
+			continue
+		}
+		key := issue.Fields.Parent.Key
+		if inVersion[key] || requested[key] {
+			continue
+		}
+		requested[key] = true
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	fmt.Fprint(os.Stderr, "⏳ Recupero genitori dei sub-task...")
+	fetched, failures := jira.GetIssues(ctx, jiraClient, keys)
+	if err := ctx.Err(); err != nil {
+		fmt.Fprintln(os.Stderr, " ❌")
+		return nil, fmt.Errorf("recupero ticket interrotto: %w", err)
+	}
+	if len(failures) > 0 {
+		fmt.Fprintf(os.Stderr, " ⚠️  (%d trovati, %d non recuperati)\n", len(fetched), len(failures))
+		for _, failure := range failures {
+			fmt.Fprintf(os.Stderr, "   - %s\n", failure.Error())
+		}
+	} else {
+		fmt.Fprintf(os.Stderr, " ✓ (%d trovati)\n", len(fetched))
+	}
+
+	parents := make(map[string]jira.Issue, len(fetched))
+	for _, parent := range fetched {
+		parents[parent.Key] = parent
+	}
+	return parents, nil
+}
+
+// printLint stampa i problemi trovati, raggruppati per controllo
+func printLint(version *jira.Version, issues int, problems []lint.Problem) {
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	fmt.Printf("  LINT DELLA VERSIONE '%s' (%d ticket)\n", version.Name, issues)
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
+
+	fixable := 0
+	for _, check := range lint.Checks {
+		var found []lint.Problem
+		for _, problem := range problems {
+			if problem.Check == check {
+				found = append(found, problem)
+			}
+		}
+
+		if len(found) == 0 {
+			fmt.Printf("✅ %s\n", lint.Descriptions[check])
+			continue
+		}
+		fmt.Printf("⚠️  %s (%d)\n", lint.Descriptions[check], len(found))
+		for _, problem := range found {
+			marker := ""
+			if problem.Fix != nil {
+				marker = " 🔧"
+				fixable++
+			}
+			fmt.Printf("   - [%s] %s — %s%s\n", problem.Issue.Key, problem.Issue.Fields.Summary, problem.Detail, marker)
+		}
+	}
+
+	fmt.Printf("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	if len(problems) == 0 {
+		fmt.Printf("  Nessun problema trovato\n")
+	} else {
+		fmt.Printf("  %d problemi trovati, %d correggibili con --fix (🔧)\n", len(problems), fixable)
+	}
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+}
+
+// applyLintFixes mostra le correzioni proposte e, dopo conferma, aggiorna le fixVersion dei ticket
+func applyLintFixes(ctx context.Context, fixes []jira.FixVersionUpdate, yes bool) error {
+	fmt.Fprintln(os.Stderr)
+	if len(fixes) == 0 {
+		fmt.Fprintln(os.Stderr, "ℹ️  Nessuna correzione automatica da applicare.")
+		return nil
+	}
+
+	if err := printFixVersionUpdates(fixes); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr)
+
+	if !yes {
+		confirmed, err := confirm(fmt.Sprintf("Aggiornare le fixVersion di %d ticket?", len(fixes)))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintln(os.Stderr, "ℹ️  Nessuna modifica applicata.")
+			return nil
+		}
+	}
+
+	errs, err := applyFixVersionUpdates(ctx, fixes)
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d ticket su %d non aggiornati", failed, len(fixes))
+	}
+	fmt.Fprintf(os.Stderr, "✅ FixVersion aggiornate su %d ticket\n", len(fixes))
+	return nil
+}
+
+// printFixVersionUpdates stampa su stderr l'anteprima delle modifiche alle fixVersion
+func printFixVersionUpdates(updates []jira.FixVersionUpdate) error {
+	fmt.Fprintf(os.Stderr, "🔧 Modifiche proposte (%d ticket):\n\n", len(updates))
+
+	w := tabwriter.NewWriter(os.Stderr, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TICKET\tSOMMARIO\tFIXVERSION ATTUALI\tAGGIUNGI\tRIMUOVI")
+	for _, update := range updates {
+		current := make([]string, 0, len(update.Issue.Fields.FixVersions))
+		for _, version := range update.Issue.Fields.FixVersions {
+			current = append(current, version.Name)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", update.Issue.Key, truncateText(update.Issue.Fields.Summary, 50),
+			joinOrDash(current), joinOrDash(update.Add), joinOrDash(update.Remove))
+	}
+	return w.Flush()
+}
+
+// applyFixVersionUpdates aggiorna le fixVersion dei ticket uno alla volta, riportando l'esito di ciascuno.
+// Restituisce un errore per ticket (nil se aggiornato); si interrompe solo se il contesto viene cancellato.
+func applyFixVersionUpdates(ctx context.Context, updates []jira.FixVersionUpdate) ([]error, error) {
+	errs := make([]error, len(updates))
+	for i, update := range updates {
+		if err := jira.UpdateFixVersions(ctx, jiraClient, update.Issue.Key, update.FixVersionChange); err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			errs[i] = err
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "✅ %s aggiornato\n", update.Issue.Key)
+	}
+	return errs, nil
+}
+
+// joinOrDash unisce i valori separandoli con una virgola, o restituisce "-" se la lista è vuota
+func joinOrDash(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ", ")
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+	lintCmd.Flags().Bool("fix", false, "Propone le correzioni delle fixVersion e le applica dopo conferma")
+	lintCmd.Flags().BoolP("yes", "y", false, "Applica le correzioni di --fix senza chiedere conferma")
+	lintCmd.Flags().Bool("strict", false, "Termina con errore se vengono trovati problemi")
+	addVersionFlags(lintCmd)
+	addIssueFilterFlags(lintCmd, jira.StatusAll)
+}
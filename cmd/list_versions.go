@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"jira-release-manager/internal/jira"
+	"jira-release-manager/internal/output"
 	"os"
 	"text/tabwriter"
 
@@ -14,15 +15,21 @@ var listVersionsCmd = &cobra.Command{
 	Short: "Mostra una tabella di tutte le versioni per un progetto.",
 	Long: `Recupera tutte le versioni (rilasciate, non rilasciate, archiviate) 
 per un progetto e le mostra in una tabella.`,
-	Example: `  jira-release-manager list-versions -p PROJ`,
+	Example: `  jira-release-manager list-versions -p PROJ
+  jira-release-manager list-versions -p PROJ --output-format json`,
 
 	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Printf("🔎 Ricerca versioni per il progetto %s...\n\n", projectKey)
-		versions, err := jira.GetAllProjectVersions(jiraClient, projectKey)
+		ctx := cmd.Context()
+		fmt.Fprintf(os.Stderr, "🔎 Ricerca versioni per il progetto %s...\n\n", projectKey)
+		versions, err := jira.GetAllProjectVersions(ctx, jiraClient, projectKey)
 		if err != nil {
 			return err
 		}
 
+		if outputFormat.IsStructured() {
+			return output.Write(os.Stdout, outputFormat, output.NewVersions(versions))
+		}
+
 		if len(versions) == 0 {
 			fmt.Println("Nessuna versione trovata per questo progetto.")
 			return nil
@@ -2,10 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"jira-release-manager/internal/jira"
 	"jira-release-manager/internal/organizer"
+	"jira-release-manager/internal/output"
 
 	"github.com/spf13/cobra"
 )
@@ -14,15 +16,25 @@ var nextReleaseCmd = &cobra.Command{
 	Use:   "next-release",
 	Short: "Mostra i ticket di una versione specifica.",
 	Long: `Permette di selezionare interattivamente una versione e 
-visualizza tutti i ticket (inclusi i sub-task) pianificati.`,
+visualizza tutti i ticket (inclusi i sub-task) pianificati.
+Per default sono esclusi i ticket completati: usare --status per cambiare il filtro.`,
 	Example: `  jira-release-manager next-release -p PROJ
-  jira-release-manager next-release -p PROJ --detailed`,
+  jira-release-manager next-release -p PROJ --detailed
+  jira-release-manager next-release -p PROJ --next
+  jira-release-manager next-release -p PROJ --version "2.4.*"
+  jira-release-manager next-release -p PROJ --next --output-format json`,
 
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		detailed, _ := cmd.Flags().GetBool("detailed")
 		debug, _ := cmd.Flags().GetBool("debug")
 
-		versionToFetch, err := selectJiraVersion(jiraClient, projectKey)
+		filter, err := issueFilterFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		versionToFetch, err := resolveJiraVersion(cmd, jiraClient, projectKey)
 		if err != nil {
 			return err
 		}
@@ -32,27 +44,31 @@ visualizza tutti i ticket (inclusi i sub-task) pianificati.`,
 			releaseDate = versionToFetch.ReleaseDate
 		}
 
-		fmt.Printf("✅ Release selezionata: %s (Data: %s)\n", versionToFetch.Name, releaseDate)
+		fmt.Fprintf(os.Stderr, "✅ Release selezionata: %s (Data: %s)\n", versionToFetch.Name, releaseDate)
 		if versionToFetch.Description != "" {
-			fmt.Printf("   Descrizione: %s\n", versionToFetch.Description)
+			fmt.Fprintf(os.Stderr, "   Descrizione: %s\n", versionToFetch.Description)
 		}
-		fmt.Println()
+		fmt.Fprintln(os.Stderr)
 
-		issues, err := jira.GetIssuesForVersion(jiraClient, projectKey, versionToFetch.Name)
+		issues, err := jira.GetIssuesForVersion(ctx, jiraClient, projectKey, versionToFetch.Name, filter)
 		if err != nil {
 			return fmt.Errorf("errore nel recupero dei ticket: %w", err)
 		}
 
+		hierarchy := organizer.NewReleaseHierarchy(issues, debug)
+
+		if outputFormat.IsStructured() {
+			return output.Write(os.Stdout, outputFormat, output.NewRelease(versionToFetch, hierarchy, jiraClient.BaseURL))
+		}
+
 		if len(issues) == 0 {
 			fmt.Println("⚠️  Nessun ticket trovato per questa versione.")
 			return nil
 		}
 
-		hierarchy := organizer.NewReleaseHierarchy(issues, debug)
-
 		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 		fmt.Printf("  TICKET PIANIFICATI PER LA VERSIONE '%s'\n", versionToFetch.Name)
-		fmt.Printf("  (Esclusi i ticket completati)\n")
+		fmt.Printf("  (%s)\n", filter.Description())
 		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
 
 		// Contatori
@@ -217,4 +233,6 @@ func init() {
 	rootCmd.AddCommand(nextReleaseCmd)
 	nextReleaseCmd.Flags().BoolP("detailed", "d", false, "Mostra informazioni dettagliate per ogni ticket")
 	nextReleaseCmd.Flags().Bool("debug", false, "Mostra informazioni di debug sulla gerarchia")
+	addVersionFlags(nextReleaseCmd)
+	addIssueFilterFlags(nextReleaseCmd, jira.StatusOpen)
 }
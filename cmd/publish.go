@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"jira-release-manager/internal/jira"
+	"jira-release-manager/internal/organizer"
+	"jira-release-manager/internal/publish"
+	"jira-release-manager/internal/templates"
+
+	"github.com/spf13/cobra"
+)
+
+var publishCmd = &cobra.Command{
+	Use:   "publish",
+	Short: "Pubblica il changelog di una versione su piattaforme esterne.",
+	Long: `Genera il changelog della versione selezionata e lo pubblica direttamente
+sulla piattaforma scelta, senza passare dal copia-incolla.`,
+}
+
+// addPublishFlags registra i flag comuni ai sottocomandi di publish.
+func addPublishFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("include-subtasks", "s", false, "Includi i sub-task nel changelog")
+	cmd.Flags().Bool("dry-run", false, "Stampa il contenuto da pubblicare senza inviarlo")
+	addVersionFlags(cmd)
+	addIssueFilterFlags(cmd, jira.StatusOpen)
+}
+
+// fetchPublishRelease risolve la versione dai flag del comando e ne recupera i ticket.
+func fetchPublishRelease(cmd *cobra.Command) (publish.Release, error) {
+	ctx := cmd.Context()
+	includeSubtasks, _ := cmd.Flags().GetBool("include-subtasks")
+
+	filter, err := issueFilterFromFlags(cmd)
+	if err != nil {
+		return publish.Release{}, err
+	}
+
+	version, err := resolveJiraVersion(cmd, jiraClient, projectKey)
+	if err != nil {
+		return publish.Release{}, err
+	}
+	fmt.Fprintf(os.Stderr, "✅ Generazione changelog per la versione: %s\n", version.Name)
+
+	issues, err := jira.GetIssuesForVersion(ctx, jiraClient, projectKey, version.Name, filter)
+	if err != nil {
+		return publish.Release{}, fmt.Errorf("errore nel recupero dei ticket: %w", err)
+	}
+
+	hierarchy := organizer.NewReleaseHierarchy(issues, false)
+	return publish.NewRelease(version, hierarchy, includeSubtasks, jiraClient.BaseURL, projectKey), nil
+}
+
+// addReleaseFlags registra i flag comuni alla creazione di release su GitHub e GitLab.
+func addReleaseFlags(cmd *cobra.Command) {
+	cmd.Flags().String("tag", "", "Tag della release (es. v2.4.0)")
+	cmd.Flags().String("name", "", "Nome della release (default: il nome della versione Jira)")
+	cmd.Flags().String("ref", "", "Commit o branch da cui creare il tag, se non esiste ancora")
+	cmd.Flags().String("api-url", "", "Indirizzo delle API, per istanze self-hosted")
+	cmd.Flags().String("token", "", "Token di accesso alle API")
+	cmd.Flags().StringP("template", "t", "", "File di template (text/template) personalizzato per la descrizione della release")
+	_ = cmd.MarkFlagRequired("tag")
+	addPublishFlags(cmd)
+}
+
+// loadReleaseTemplate carica il template della descrizione: quello indicato con --template
+// o il layout Markdown predefinito.
+func loadReleaseTemplate(cmd *cobra.Command) (*templates.Template, error) {
+	templateFile, _ := cmd.Flags().GetString("template")
+	if templateFile != "" {
+		return templates.FromFile(templateFile)
+	}
+	return templates.Builtin("markdown")
+}
+
+// releaseInputFromFlags rende il changelog con il template e lo combina con i flag della release.
+func releaseInputFromFlags(cmd *cobra.Command, tmpl *templates.Template, release publish.Release) (publish.ReleaseInput, error) {
+	tag, _ := cmd.Flags().GetString("tag")
+	name, _ := cmd.Flags().GetString("name")
+	ref, _ := cmd.Flags().GetString("ref")
+
+	body, err := templates.Render(tmpl, release.Data)
+	if err != nil {
+		return publish.ReleaseInput{}, err
+	}
+	if name == "" {
+		name = release.Version.Name
+	}
+
+	return publish.ReleaseInput{Tag: tag, Name: name, Body: body, Ref: ref}, nil
+}
+
+func init() {
+	rootCmd.AddCommand(publishCmd)
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"jira-release-manager/internal/jira"
+	"jira-release-manager/internal/publish"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var publishConfluenceCmd = &cobra.Command{
+	Use:   "confluence",
+	Short: "Pubblica il changelog come pagina Confluence.",
+	Long: `Converte i ticket della versione nello storage format di Confluence, con la macro Jira
+per ogni ticket, e crea una pagina intitolata alla versione. Se nello spazio esiste già una
+pagina con lo stesso titolo, ne viene pubblicata una nuova versione.
+Usa le stesse credenziali del client Jira. Su Jira Cloud Confluence viene raggiunto
+su <JIRA_URL>/wiki; su Server/Data Center va indicato con --confluence-url (o CONFLUENCE_URL).`,
+	Example: `  jira-release-manager publish confluence -p PROJ --next --space REL --parent-id 123456
+  jira-release-manager publish confluence -p PROJ --version 2.4.0 --space REL --title "Backend 2.4.0"
+  jira-release-manager publish confluence -p PROJ --next --dry-run`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		spaceKey, _ := cmd.Flags().GetString("space")
+		parentID, _ := cmd.Flags().GetString("parent-id")
+		title, _ := cmd.Flags().GetString("title")
+		confluenceURL, _ := cmd.Flags().GetString("confluence-url")
+		jiraServerID, _ := cmd.Flags().GetString("jira-server-id")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		// Lo spazio serve solo per pubblicare: il dry-run si limita a generare il contenuto
+		if spaceKey == "" && !dryRun {
+			return fmt.Errorf("il flag --space è obbligatorio per pubblicare la pagina")
+		}
+		if confluenceURL == "" {
+			confluenceURL = viper.GetString("CONFLUENCE_URL")
+		}
+		if confluenceURL == "" {
+			if jiraClient.Deployment != jira.DeploymentCloud {
+				return fmt.Errorf("su Jira Server/Data Center specifica l'indirizzo di Confluence con --confluence-url (o CONFLUENCE_URL)")
+			}
+			confluenceURL = jiraClient.BaseURL + "/wiki"
+		}
+		if jiraServerID == "" {
+			jiraServerID = viper.GetString("CONFLUENCE_JIRA_SERVER_ID")
+		}
+
+		release, err := fetchPublishRelease(cmd)
+		if err != nil {
+			return err
+		}
+		if title == "" {
+			title = fmt.Sprintf("Changelog - Versione %s", release.Version.Name)
+		}
+
+		body := publish.ConfluenceStorage(release, jiraServerID)
+		if dryRun {
+			fmt.Print(body)
+			return nil
+		}
+
+		fmt.Fprintf(os.Stderr, "⏳ Pubblicazione della pagina '%s' nello spazio %s...", title, spaceKey)
+		page, created, err := publish.PublishConfluencePage(ctx, jiraClient.WithBaseURL(confluenceURL), publish.ConfluencePageInput{
+			SpaceKey: spaceKey,
+			ParentID: parentID,
+			Title:    title,
+			Body:     body,
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, " ❌")
+			return err
+		}
+		fmt.Fprintln(os.Stderr, " ✓")
+
+		if created {
+			fmt.Fprintf(os.Stderr, "✅ Pagina creata: %s\n", page.URL())
+		} else {
+			fmt.Fprintf(os.Stderr, "✅ Pagina aggiornata (versione %d): %s\n", page.Version.Number, page.URL())
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	publishCmd.AddCommand(publishConfluenceCmd)
+	publishConfluenceCmd.Flags().String("space", "", "Chiave dello spazio Confluence (obbligatoria, tranne con --dry-run)")
+	publishConfluenceCmd.Flags().String("parent-id", "", "ID della pagina genitore")
+	publishConfluenceCmd.Flags().String("title", "", "Titolo della pagina (default: \"Changelog - Versione <versione>\")")
+	publishConfluenceCmd.Flags().String("confluence-url", "", "Indirizzo di Confluence (default su Cloud: <JIRA_URL>/wiki)")
+	publishConfluenceCmd.Flags().String("jira-server-id", "", "ID del collegamento applicativo verso Jira, se Confluence ne ha più di uno")
+	addPublishFlags(publishConfluenceCmd)
+}
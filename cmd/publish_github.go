@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"jira-release-manager/internal/publish"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var publishGitHubCmd = &cobra.Command{
+	Use:   "github",
+	Short: "Crea o aggiorna una release GitHub con il changelog della versione.",
+	Long: `Genera il changelog Markdown della versione e lo usa come descrizione della release
+GitHub associata al tag. Se la release esiste già, ne aggiorna nome e descrizione.
+Il token può essere indicato anche con GITHUB_TOKEN; per GitHub Enterprise l'indirizzo
+delle API (https://<host>/api/v3) con --api-url o GITHUB_API_URL.`,
+	Example: `  jira-release-manager publish github -p PROJ --next --repo acme/backend --tag v2.4.0
+  jira-release-manager publish github -p PROJ --version 2.4.0 --repo acme/backend --tag v2.4.0 --draft
+  jira-release-manager publish github -p PROJ --next --repo acme/backend --tag v2.4.0 --api-url https://github.acme.com/api/v3`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		repo, _ := cmd.Flags().GetString("repo")
+		apiURL, _ := cmd.Flags().GetString("api-url")
+		token, _ := cmd.Flags().GetString("token")
+		draft, _ := cmd.Flags().GetBool("draft")
+		prerelease, _ := cmd.Flags().GetBool("prerelease")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		if apiURL == "" {
+			apiURL = viper.GetString("GITHUB_API_URL")
+		}
+		if apiURL == "" {
+			apiURL = publish.DefaultGitHubAPIURL
+		}
+		if token == "" {
+			token = viper.GetString("GITHUB_TOKEN")
+		}
+		if token == "" && !dryRun {
+			return fmt.Errorf("specifica il token con --token (o GITHUB_TOKEN), oppure usa --dry-run")
+		}
+
+		// Il template va validato prima di interrogare Jira
+		tmpl, err := loadReleaseTemplate(cmd)
+		if err != nil {
+			return err
+		}
+
+		release, err := fetchPublishRelease(cmd)
+		if err != nil {
+			return err
+		}
+
+		input, err := releaseInputFromFlags(cmd, tmpl, release)
+		if err != nil {
+			return err
+		}
+		input.Draft = draft
+		input.Prerelease = prerelease
+
+		if dryRun {
+			fmt.Fprintf(os.Stderr, "ℹ️  Release '%s' (tag %s) su %s\n\n", input.Name, input.Tag, repo)
+			fmt.Println(input.Body)
+			return nil
+		}
+
+		fmt.Fprintf(os.Stderr, "⏳ Pubblicazione della release %s su %s...", input.Tag, repo)
+		githubRelease, created, err := publish.NewGitHubClient(apiURL, token).PublishRelease(ctx, repo, input)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, " ❌")
+			return err
+		}
+		fmt.Fprintln(os.Stderr, " ✓")
+
+		if created {
+			fmt.Fprintf(os.Stderr, "✅ Release creata: %s\n", githubRelease.HTMLURL)
+		} else {
+			fmt.Fprintf(os.Stderr, "✅ Release aggiornata: %s\n", githubRelease.HTMLURL)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	publishCmd.AddCommand(publishGitHubCmd)
+	publishGitHubCmd.Flags().String("repo", "", "Repository GitHub nel formato owner/nome")
+	publishGitHubCmd.Flags().Bool("draft", false, "Crea la release come bozza")
+	publishGitHubCmd.Flags().Bool("prerelease", false, "Segna la release come pre-release")
+	_ = publishGitHubCmd.MarkFlagRequired("repo")
+	addReleaseFlags(publishGitHubCmd)
+}
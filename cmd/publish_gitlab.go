@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"jira-release-manager/internal/publish"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var publishGitLabCmd = &cobra.Command{
+	Use:   "gitlab",
+	Short: "Crea o aggiorna una release GitLab con il changelog della versione.",
+	Long: `Genera il changelog Markdown della versione e lo usa come descrizione della release
+GitLab associata al tag. Se la release esiste già, ne aggiorna nome e descrizione.
+Il token può essere indicato anche con GITLAB_TOKEN; per un'istanza self-hosted l'indirizzo
+delle API (https://<host>/api/v4) con --api-url o GITLAB_API_URL.`,
+	Example: `  jira-release-manager publish gitlab -p PROJ --next --project-id 1234 --tag v2.4.0
+  jira-release-manager publish gitlab -p PROJ --next --project-id acme/backend --tag v2.4.0 --ref main
+  jira-release-manager publish gitlab -p PROJ --next --project-id 1234 --tag v2.4.0 --api-url https://gitlab.acme.com/api/v4`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		project, _ := cmd.Flags().GetString("project-id")
+		apiURL, _ := cmd.Flags().GetString("api-url")
+		token, _ := cmd.Flags().GetString("token")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		if apiURL == "" {
+			apiURL = viper.GetString("GITLAB_API_URL")
+		}
+		if apiURL == "" {
+			apiURL = publish.DefaultGitLabAPIURL
+		}
+		if token == "" {
+			token = viper.GetString("GITLAB_TOKEN")
+		}
+		if token == "" && !dryRun {
+			return fmt.Errorf("specifica il token con --token (o GITLAB_TOKEN), oppure usa --dry-run")
+		}
+
+		// Il template va validato prima di interrogare Jira
+		tmpl, err := loadReleaseTemplate(cmd)
+		if err != nil {
+			return err
+		}
+
+		release, err := fetchPublishRelease(cmd)
+		if err != nil {
+			return err
+		}
+
+		input, err := releaseInputFromFlags(cmd, tmpl, release)
+		if err != nil {
+			return err
+		}
+
+		if dryRun {
+			fmt.Fprintf(os.Stderr, "ℹ️  Release '%s' (tag %s) sul progetto %s\n\n", input.Name, input.Tag, project)
+			fmt.Println(input.Body)
+			return nil
+		}
+
+		fmt.Fprintf(os.Stderr, "⏳ Pubblicazione della release %s sul progetto %s...", input.Tag, project)
+		gitlabRelease, created, err := publish.NewGitLabClient(apiURL, token).PublishRelease(ctx, project, input)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, " ❌")
+			return err
+		}
+		fmt.Fprintln(os.Stderr, " ✓")
+
+		if created {
+			fmt.Fprintf(os.Stderr, "✅ Release creata: %s\n", gitlabRelease.Links.Self)
+		} else {
+			fmt.Fprintf(os.Stderr, "✅ Release aggiornata: %s\n", gitlabRelease.Links.Self)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	publishCmd.AddCommand(publishGitLabCmd)
+	publishGitLabCmd.Flags().String("project-id", "", "Progetto GitLab: ID numerico o percorso gruppo/nome")
+	_ = publishGitLabCmd.MarkFlagRequired("project-id")
+	addReleaseFlags(publishGitLabCmd)
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"jira-release-manager/internal/publish"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var publishSlackCmd = &cobra.Command{
+	Use:   "slack",
+	Short: "Pubblica il changelog su un canale Slack tramite incoming webhook.",
+	Long: `Converte i ticket della versione in messaggi Block Kit (header, una section per ogni
+epic e tipo di issue, blocchi di contesto con i conteggi) e li invia a un incoming webhook di Slack.
+Se il changelog supera i limiti di Slack (50 blocchi, 3000 caratteri per section) viene
+suddiviso in più messaggi, inviati in ordine.
+L'URL del webhook può essere indicato anche con la variabile SLACK_WEBHOOK_URL.`,
+	Example: `  jira-release-manager publish slack -p PROJ --next --webhook-url https://hooks.slack.com/services/...
+  jira-release-manager publish slack -p PROJ --version 2.4.0 --dry-run`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		webhookURL, _ := cmd.Flags().GetString("webhook-url")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		if webhookURL == "" {
+			webhookURL = viper.GetString("SLACK_WEBHOOK_URL")
+		}
+		if webhookURL == "" && !dryRun {
+			return fmt.Errorf("specifica l'URL del webhook con --webhook-url (o SLACK_WEBHOOK_URL), oppure usa --dry-run")
+		}
+
+		release, err := fetchPublishRelease(cmd)
+		if err != nil {
+			return err
+		}
+
+		messages := publish.NewSlackMessages(release)
+
+		if dryRun {
+			payload, err := publish.MarshalIndent(messages)
+			if err != nil {
+				return fmt.Errorf("errore nella serializzazione dei messaggi: %w", err)
+			}
+			fmt.Println(string(payload))
+			return nil
+		}
+
+		for i, message := range messages {
+			fmt.Fprintf(os.Stderr, "⏳ Invio del messaggio %d/%d a Slack...", i+1, len(messages))
+			if err := publish.PostWebhook(ctx, webhookURL, message); err != nil {
+				fmt.Fprintln(os.Stderr, " ❌")
+				return err
+			}
+			fmt.Fprintln(os.Stderr, " ✓")
+		}
+		fmt.Fprintf(os.Stderr, "✅ Changelog della versione %s pubblicato su Slack\n", release.Version.Name)
+
+		return nil
+	},
+}
+
+func init() {
+	publishCmd.AddCommand(publishSlackCmd)
+	publishSlackCmd.Flags().String("webhook-url", "", "URL dell'incoming webhook di Slack")
+	addPublishFlags(publishSlackCmd)
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"jira-release-manager/internal/publish"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var publishTeamsCmd = &cobra.Command{
+	Use:   "teams",
+	Short: "Pubblica il changelog su un canale Microsoft Teams come Adaptive Card.",
+	Long: `Converte i ticket della versione in una Adaptive Card (dati della versione,
+una sezione espandibile per ogni epic e tipo di issue, pulsanti verso la release in Jira)
+e la invia a un incoming webhook di Teams.
+L'URL del webhook può essere indicato anche con la variabile TEAMS_WEBHOOK_URL.`,
+	Example: `  jira-release-manager publish teams -p PROJ --next --webhook-url https://example.webhook.office.com/...
+  jira-release-manager publish teams -p PROJ --version 2.4.0 --dry-run`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		webhookURL, _ := cmd.Flags().GetString("webhook-url")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		if webhookURL == "" {
+			webhookURL = viper.GetString("TEAMS_WEBHOOK_URL")
+		}
+		if webhookURL == "" && !dryRun {
+			return fmt.Errorf("specifica l'URL del webhook con --webhook-url (o TEAMS_WEBHOOK_URL), oppure usa --dry-run")
+		}
+
+		release, err := fetchPublishRelease(cmd)
+		if err != nil {
+			return err
+		}
+
+		message := publish.NewTeamsMessage(release)
+		payload, err := publish.MarshalIndent(message)
+		if err != nil {
+			return fmt.Errorf("errore nella serializzazione della card: %w", err)
+		}
+		if len(payload) > publish.TeamsMaxPayloadSize {
+			fmt.Fprintf(os.Stderr, "⚠️  La card occupa %d KB e potrebbe superare il limite di Teams (%d KB): valuta --status o --jql-filter.\n",
+				len(payload)/1024, publish.TeamsMaxPayloadSize/1024)
+		}
+
+		if dryRun {
+			fmt.Println(string(payload))
+			return nil
+		}
+
+		fmt.Fprint(os.Stderr, "⏳ Invio della card a Teams...")
+		if err := publish.PostWebhook(ctx, webhookURL, message); err != nil {
+			fmt.Fprintln(os.Stderr, " ❌")
+			return err
+		}
+		fmt.Fprintln(os.Stderr, " ✓")
+		fmt.Fprintf(os.Stderr, "✅ Changelog della versione %s pubblicato su Teams\n", release.Version.Name)
+
+		return nil
+	},
+}
+
+func init() {
+	publishCmd.AddCommand(publishTeamsCmd)
+	publishTeamsCmd.Flags().String("webhook-url", "", "URL dell'incoming webhook di Teams")
+	addPublishFlags(publishTeamsCmd)
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"jira-release-manager/internal/jira"
+	"jira-release-manager/internal/output"
+	"jira-release-manager/internal/readiness"
+
+	"github.com/spf13/cobra"
+)
+
+var readinessCmd = &cobra.Command{
+	Use:   "readiness",
+	Short: "Valuta se una versione è pronta per il rilascio.",
+	Long: `Valuta i ticket di una versione rispetto a un insieme di regole e restituisce un esito
+pass/warn/fail. Con esito fail (o warn con --strict) il comando termina con codice di uscita
+diverso da zero, così da poter essere usato in CI come controllo prima del deploy.
+
+Regole disponibili (--rules regola=fail|warn|off):
+  blocked     nessun ticket in uno stato bloccante (--blocked-statuses)             default: fail
+  unassigned  nessun ticket aperto ad alta priorità senza assegnatario (--high-priority) default: warn
+  subtasks    tutti i sub-task completati                                            default: fail
+  bugs        nessun bug aperto sopra la priorità indicata (--max-bug-priority)      default: fail
+  labels      ogni ticket (esclusi epic e sub-task) ha un'etichetta di repository    default: warn`,
+	Example: `  jira-release-manager readiness -p PROJ --next
+  jira-release-manager readiness -p PROJ --version 2.4.0 --rules labels=fail,unassigned=off
+  jira-release-manager readiness -p PROJ --next --max-bug-priority Low --repo-labels api,web --strict
+  jira-release-manager readiness -p PROJ --next --output-format json`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		strict, _ := cmd.Flags().GetBool("strict")
+		rules, _ := cmd.Flags().GetString("rules")
+		blockedStatuses, _ := cmd.Flags().GetStringSlice("blocked-statuses")
+		highPriority, _ := cmd.Flags().GetString("high-priority")
+		maxBugPriority, _ := cmd.Flags().GetString("max-bug-priority")
+		bugTypes, _ := cmd.Flags().GetStringSlice("bug-types")
+		repoLabels, _ := cmd.Flags().GetStringSlice("repo-labels")
+
+		levels, err := readiness.ParseLevels(rules)
+		if err != nil {
+			return err
+		}
+		opts := readiness.Options{
+			Levels:          levels,
+			BlockedStatuses: blockedStatuses,
+			HighPriority:    highPriority,
+			MaxBugPriority:  maxBugPriority,
+			BugTypes:        bugTypes,
+			RepoLabels:      repoLabels,
+			SubtaskTypes:    jiraClient.SubtaskTypes,
+		}
+
+		// L'ordine delle priorità viene da Jira, così valgono anche gli schemi personalizzati
+		priorities, err := jira.GetPriorities(ctx, jiraClient)
+		if err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "⚠️  %v: uso gli schemi di priorità predefiniti\n", err)
+		}
+		for _, priority := range priorities {
+			opts.Priorities = append(opts.Priorities, priority.Name)
+		}
+		if err := opts.Validate(); err != nil {
+			return err
+		}
+
+		filter, err := issueFilterFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		version, err := resolveJiraVersion(cmd, jiraClient, projectKey)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "🔎 Verifica readiness della versione: %s\n", version.Name)
+
+		issues, err := jira.GetIssuesForVersion(ctx, jiraClient, projectKey, version.Name, filter)
+		if err != nil {
+			return fmt.Errorf("errore nel recupero dei ticket: %w", err)
+		}
+
+		report := readiness.Evaluate(issues, opts)
+
+		if outputFormat.IsStructured() {
+			if err := output.Write(os.Stdout, outputFormat, output.NewReadiness(version, report, jiraClient.BaseURL)); err != nil {
+				return err
+			}
+		} else {
+			printReadiness(version, report)
+		}
+
+		failed := report.Count(readiness.OutcomeFail)
+		if strict {
+			failed += report.Count(readiness.OutcomeWarn)
+		}
+		if failed > 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("la versione %s non è pronta per il rilascio: %d regole non superate", version.Name, failed)
+		}
+		return nil
+	},
+}
+
+// readinessLabels sono le etichette con cui viene mostrato l'esito di una regola
+var readinessLabels = map[readiness.Outcome]string{
+	readiness.OutcomePass: "✅ PASS",
+	readiness.OutcomeWarn: "⚠️  WARN",
+	readiness.OutcomeFail: "❌ FAIL",
+	readiness.OutcomeSkip: "⏭️  SKIP",
+}
+
+// printReadiness stampa l'esito delle regole con i ticket che le violano
+func printReadiness(version *jira.Version, report readiness.Report) {
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	fmt.Printf("  READINESS DELLA VERSIONE '%s' (%d ticket)\n", version.Name, report.Issues)
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
+
+	for _, result := range report.Results {
+		line := fmt.Sprintf("%s  %s", readinessLabels[result.Outcome], result.Description)
+		if len(result.Violations) > 0 {
+			line += fmt.Sprintf(" (%d)", len(result.Violations))
+		}
+		fmt.Println(line)
+		for _, violation := range result.Violations {
+			fmt.Printf("         - [%s] %s — %s\n", violation.Issue.Key, violation.Issue.Fields.Summary, violation.Detail)
+		}
+	}
+
+	fmt.Printf("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	fmt.Printf("  ESITO: %s (%d superate, %d avvisi, %d non superate",
+		strings.TrimSpace(readinessLabels[report.Outcome]),
+		report.Count(readiness.OutcomePass), report.Count(readiness.OutcomeWarn), report.Count(readiness.OutcomeFail))
+	if skipped := report.Count(readiness.OutcomeSkip); skipped > 0 {
+		fmt.Printf(", %d disattivate", skipped)
+	}
+	fmt.Printf(")\n")
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+}
+
+func init() {
+	rootCmd.AddCommand(readinessCmd)
+	readinessCmd.Flags().String("rules", "", "Livelli delle regole: regola=fail|warn|off separati da virgola (es. labels=off,unassigned=fail)")
+	readinessCmd.Flags().StringSlice("blocked-statuses", []string{"Blocked"}, "Stati considerati bloccanti")
+	readinessCmd.Flags().String("high-priority", "High", "Priorità minima per cui un ticket aperto deve avere un assegnatario")
+	readinessCmd.Flags().String("max-bug-priority", "Medium", "Priorità massima ammessa per i bug ancora aperti")
+	readinessCmd.Flags().StringSlice("bug-types", []string{"Bug"}, "Tipi di issue considerati bug")
+	readinessCmd.Flags().StringSlice("repo-labels", nil, "Etichette di repository ammesse (default: qualsiasi etichetta)")
+	readinessCmd.Flags().Bool("strict", false, "Considera gli avvisi come regole non superate")
+	addVersionFlags(readinessCmd)
+	addIssueFilterFlags(readinessCmd, jira.StatusAll)
+}
@@ -1,11 +1,18 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
+	"jira-release-manager/internal/config"
 	"jira-release-manager/internal/jira"
+	"jira-release-manager/internal/output"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -20,16 +27,46 @@ var rootCmd = &cobra.Command{
 - Avere una overview completa di ticket e sub-task`,
 
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Scadenza complessiva del comando, propagata a tutte le chiamate Jira
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		if timeout > 0 {
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			cmd.SetContext(ctx)
+			cancelTimeout = cancel
+		}
+
+		format, _ := cmd.Flags().GetString("output-format")
 		var err error
+		outputFormat, err = output.ParseFormat(format)
+		if err != nil {
+			return err
+		}
+
+		// I comandi che gestiscono la configurazione funzionano anche senza credenziali o con un file non valido
+		if !needsJiraClient(cmd) {
+			return nil
+		}
+		if configErr != nil {
+			return configErr
+		}
+		if activeProfile != nil {
+			if err := applyFlagDefaults(cmd, activeProfile.FlagDefaults()); err != nil {
+				return err
+			}
+		}
+
 		projectKey, err = cmd.Flags().GetString("project")
 		if err != nil {
 			return err
 		}
+		if projectKey == "" {
+			projectKey = viper.GetString("DEFAULT_PROJECT")
+		}
 		if projectKey == "" {
 			if cmd.Name() == "help" || strings.HasPrefix(cmd.Name(), "__") {
 				return nil
 			}
-			return fmt.Errorf("il flag --project (-p) è obbligatorio")
+			return fmt.Errorf("il flag --project (-p) è obbligatorio (o imposta project nel profilo)")
 		}
 
 		jiraClient, err = jira.NewClient()
@@ -42,14 +79,44 @@ var rootCmd = &cobra.Command{
 }
 
 var (
-	projectKey string
-	jiraClient *jira.Client
+	projectKey   string
+	jiraClient   *jira.Client
+	outputFormat output.Format
+
+	// cancelTimeout rilascia il contesto creato da --timeout
+	cancelTimeout context.CancelFunc = func() {}
+
+	// Configurazione caricata da initConfig
+	configPath    string
+	configFile    *config.File
+	profileName   string
+	activeProfile *config.Profile
+	configErr     error
 )
 
-// Execute esegue il comando root
+// annotationNoJiraClient marca i comandi che non richiedono il client Jira né il progetto
+const annotationNoJiraClient = "noJiraClient"
+
+// Execute esegue il comando root. Ctrl-C (o SIGTERM) cancella il contesto del comando,
+// interrompendo le richieste in corso.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := rootCmd.ExecuteContext(ctx)
+	cancelTimeout()
+	stop()
+
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			fmt.Fprintln(os.Stderr, "💡 Tempo massimo superato: aumenta il valore di --timeout.")
+		case errors.Is(err, context.Canceled):
+			fmt.Fprintln(os.Stderr, "⚠️  Operazione annullata.")
+		case jira.IsUnauthorized(err):
+			fmt.Fprintln(os.Stderr, "💡 Verifica JIRA_USERNAME e JIRA_API_TOKEN.")
+		case jira.IsRateLimited(err):
+			fmt.Fprintln(os.Stderr, "💡 Jira sta limitando le richieste: riprova più tardi o aumenta JIRA_MAX_RETRIES.")
+		}
 		os.Exit(1)
 	}
 }
@@ -57,6 +124,10 @@ func Execute() {
 func init() {
 	cobra.OnInitialize(initConfig)
 	rootCmd.PersistentFlags().StringP("project", "p", "", "Chiave del progetto Jira (es. PROJ)")
+	rootCmd.PersistentFlags().String("output-format", "text", "Formato di output: text, table, json, yaml")
+	rootCmd.PersistentFlags().String("profile", "", "Profilo del file di configurazione da usare (default: default_profile o JIRA_PROFILE)")
+	rootCmd.PersistentFlags().String("config", "", "Percorso del file di configurazione (default: $XDG_CONFIG_HOME/jira-release-manager/config.yaml)")
+	rootCmd.PersistentFlags().Duration("timeout", 0, "Tempo massimo per l'intero comando (es. 30s, 2m); 0 = nessun limite")
 }
 
 func initConfig() {
@@ -68,4 +139,73 @@ func initConfig() {
 	viper.AddConfigPath(".")
 
 	_ = viper.ReadInConfig()
+
+	loadConfigFile()
+}
+
+// loadConfigFile carica il file di configurazione e applica il profilo selezionato.
+// Le variabili d'ambiente hanno la precedenza sul profilo, che a sua volta ha la precedenza sul file .env.
+// Gli errori vengono restituiti da PersistentPreRunE, così i comandi config possono comunque gestirli.
+func loadConfigFile() {
+	explicitPath, _ := rootCmd.PersistentFlags().GetString("config")
+	profileName, _ = rootCmd.PersistentFlags().GetString("profile")
+	if profileName == "" {
+		profileName = os.Getenv("JIRA_PROFILE")
+	}
+
+	configPath = explicitPath
+	if configPath == "" {
+		configPath, configErr = config.DefaultPath()
+		if configErr != nil {
+			return
+		}
+	}
+
+	configFile, configErr = config.Load(configPath)
+	if configErr != nil {
+		// Senza file la configurazione arriva solo da ambiente e .env, a meno che non sia stato chiesto esplicitamente
+		if errors.Is(configErr, fs.ErrNotExist) && explicitPath == "" && profileName == "" {
+			configErr = nil
+		}
+		configFile = nil
+		return
+	}
+
+	activeProfile, profileName, configErr = configFile.Resolve(profileName)
+	if configErr != nil || activeProfile == nil {
+		return
+	}
+	for key, value := range activeProfile.Settings() {
+		if _, set := os.LookupEnv(key); !set {
+			viper.Set(key, value)
+		}
+	}
+}
+
+// applyFlagDefaults imposta i valori predefiniti del profilo sui flag del comando non indicati esplicitamente
+func applyFlagDefaults(cmd *cobra.Command, defaults map[string]string) error {
+	for name, value := range defaults {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil || flag.Changed {
+			continue
+		}
+		// Un --format esplicito prevale sul template del profilo
+		if name == "template" && cmd.Flags().Changed("format") {
+			continue
+		}
+		if err := flag.Value.Set(value); err != nil {
+			return fmt.Errorf("valore non valido per --%s nel profilo '%s': %w", name, profileName, err)
+		}
+	}
+	return nil
+}
+
+// needsJiraClient indica se il comando, o uno dei suoi genitori, richiede il client Jira
+func needsJiraClient(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[annotationNoJiraClient] == "true" {
+			return false
+		}
+	}
+	return true
 }
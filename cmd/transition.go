@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"jira-release-manager/internal/jira"
+	"jira-release-manager/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var transitionCmd = &cobra.Command{
+	Use:   "transition",
+	Short: "Sposta i ticket di una versione in uno stato del workflow.",
+	Long: `Per ogni ticket della versione cerca, tra le transizioni disponibili nel suo stato attuale,
+quella che porta allo stato indicato con --to e la applica, impostando facoltativamente
+la risoluzione e aggiungendo un commento. I ticket per cui la transizione non è disponibile
+vengono ignorati e riportati nel riepilogo.`,
+	Example: `  jira-release-manager transition -p PROJ --version 2.4.0 --to Released
+  jira-release-manager transition -p PROJ --next --from "Ready for Release" --to Released --resolution Done --comment "Rilasciato nella versione 2.4.0"
+  jira-release-manager transition -p PROJ --next --to Released --dry-run`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		to, _ := cmd.Flags().GetString("to")
+		from, _ := cmd.Flags().GetString("from")
+		resolution, _ := cmd.Flags().GetString("resolution")
+		comment, _ := cmd.Flags().GetString("comment")
+		yes, _ := cmd.Flags().GetBool("yes")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		filter, err := issueFilterFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		version, err := resolveJiraVersion(cmd, jiraClient, projectKey)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "✅ Versione selezionata: %s\n", version.Name)
+
+		issues, err := jira.GetIssuesForVersion(ctx, jiraClient, projectKey, version.Name, filter)
+		if err != nil {
+			return fmt.Errorf("errore nel recupero dei ticket: %w", err)
+		}
+		sortIssuesByKey(issues)
+
+		fmt.Fprintf(os.Stderr, "⏳ Verifica delle transizioni disponibili (%d ticket)...", len(issues))
+		plans := make([]transitionPlan, 0, len(issues))
+		planned := 0
+		for _, issue := range issues {
+			plan := transitionPlan{issue: issue, resolution: resolution}
+			status := issue.Fields.Status.Name
+
+			switch {
+			case strings.EqualFold(status, to):
+				plan.outcome = "already"
+			case from != "" && !strings.EqualFold(status, from):
+				plan.outcome = "skipped"
+				plan.detail = fmt.Sprintf("non è in stato %s", from)
+			default:
+				transitions, err := jira.GetTransitions(ctx, jiraClient, issue.Key)
+				if err != nil {
+					if ctx.Err() != nil {
+						fmt.Fprintln(os.Stderr, " ❌")
+						return err
+					}
+					plan.outcome = "failed"
+					plan.detail = err.Error()
+					break
+				}
+
+				for i := range transitions {
+					if strings.EqualFold(transitions[i].To.Name, to) {
+						plan.transition = &transitions[i]
+						break
+					}
+				}
+				if plan.transition == nil {
+					plan.outcome = "unavailable"
+					plan.detail = fmt.Sprintf("nessuna transizione verso %s da %s", to, status)
+					break
+				}
+
+				plan.outcome = "planned"
+				planned++
+				// Impostare un campo assente dalla schermata di transizione fa fallire la richiesta
+				if resolution != "" && !plan.transition.HasField("resolution") {
+					plan.resolution = ""
+					plan.detail = "la transizione non prevede la risoluzione, che non verrà impostata"
+				}
+			}
+			plans = append(plans, plan)
+		}
+		fmt.Fprintln(os.Stderr, " ✓")
+		fmt.Fprintln(os.Stderr)
+
+		if err := printTransitionPlans(plans, to); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr)
+
+		writeResults := func() error {
+			if !outputFormat.IsStructured() {
+				return nil
+			}
+			results := make([]output.TransitionResult, 0, len(plans))
+			for _, plan := range plans {
+				results = append(results, plan.result(jiraClient.BaseURL))
+			}
+			return output.Write(os.Stdout, outputFormat, results)
+		}
+
+		if planned == 0 {
+			fmt.Fprintf(os.Stderr, "ℹ️  Nessun ticket da spostare in %s.\n", to)
+			return writeResults()
+		}
+		if dryRun {
+			fmt.Fprintln(os.Stderr, "ℹ️  Modalità dry-run: nessuna transizione applicata.")
+			return writeResults()
+		}
+
+		if !yes {
+			confirmed, err := confirm(fmt.Sprintf("Spostare %d ticket in %s?", planned, to))
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Fprintln(os.Stderr, "ℹ️  Nessuna transizione applicata.")
+				return writeResults()
+			}
+		}
+
+		transitioned, failed := 0, 0
+		for i := range plans {
+			plan := &plans[i]
+			if plan.outcome != "planned" {
+				continue
+			}
+			if err := jira.TransitionIssue(ctx, jiraClient, plan.issue.Key, plan.transition.ID, plan.resolution, comment); err != nil {
+				if ctx.Err() != nil {
+					return err
+				}
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				plan.outcome = "failed"
+				plan.detail = err.Error()
+				failed++
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "✅ %s: %s → %s\n", plan.issue.Key, plan.issue.Fields.Status.Name, plan.transition.To.Name)
+			plan.outcome = "transitioned"
+			transitioned++
+		}
+		fmt.Fprintln(os.Stderr)
+
+		if err := writeResults(); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "✅ %d ticket spostati in %s\n", transitioned, to)
+		if ignored := len(plans) - transitioned - failed; ignored > 0 {
+			fmt.Fprintf(os.Stderr, "ℹ️  %d ticket non spostati (vedi l'anteprima)\n", ignored)
+		}
+		if failed > 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%d transizioni su %d non riuscite", failed, planned)
+		}
+		return nil
+	},
+}
+
+// transitionPlan è la transizione prevista per un ticket e il suo esito
+type transitionPlan struct {
+	issue      jira.Issue
+	transition *jira.Transition // nil se la transizione non è disponibile o il ticket è ignorato
+	resolution string
+	outcome    string // planned, transitioned, already, skipped, unavailable, failed
+	detail     string
+}
+
+// transitionOutcomes sono le etichette con cui viene mostrato l'esito di una transizione
+var transitionOutcomes = map[string]string{
+	"planned":      "🔄 da spostare",
+	"transitioned": "✅ spostato",
+	"already":      "✅ già nello stato",
+	"skipped":      "⏭️  ignorato",
+	"unavailable":  "⚠️  non disponibile",
+	"failed":       "❌ errore",
+}
+
+// result converte il piano nello schema di output
+func (p transitionPlan) result(baseURL string) output.TransitionResult {
+	result := output.TransitionResult{
+		Key:     p.issue.Key,
+		Summary: p.issue.Fields.Summary,
+		URL:     fmt.Sprintf("%s/browse/%s", baseURL, p.issue.Key),
+		Status:  p.issue.Fields.Status.Name,
+		Outcome: p.outcome,
+		Detail:  p.detail,
+	}
+	if p.transition != nil {
+		result.Transition = p.transition.Name
+	}
+	return result
+}
+
+// printTransitionPlans stampa su stderr l'anteprima delle transizioni
+func printTransitionPlans(plans []transitionPlan, to string) error {
+	fmt.Fprintf(os.Stderr, "🔧 Transizioni verso %s:\n\n", to)
+
+	w := tabwriter.NewWriter(os.Stderr, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TICKET\tSOMMARIO\tSTATO\tTRANSIZIONE\tESITO\tNOTE")
+	for _, plan := range plans {
+		transition := "-"
+		if plan.transition != nil {
+			transition = plan.transition.Name
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", plan.issue.Key, truncateText(plan.issue.Fields.Summary, 40),
+			plan.issue.Fields.Status.Name, transition, transitionOutcomes[plan.outcome], plan.detail)
+	}
+	return w.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(transitionCmd)
+	transitionCmd.Flags().String("to", "", "Stato di destinazione (es. Released)")
+	transitionCmd.Flags().String("from", "", "Sposta solo i ticket in questo stato (es. \"Ready for Release\")")
+	transitionCmd.Flags().String("resolution", "", "Risoluzione da impostare, se prevista dalla transizione (es. Done)")
+	transitionCmd.Flags().String("comment", "", "Commento da aggiungere a ogni ticket spostato")
+	transitionCmd.Flags().BoolP("yes", "y", false, "Applica le transizioni senza chiedere conferma")
+	transitionCmd.Flags().Bool("dry-run", false, "Mostra le transizioni previste senza applicarle")
+	addVersionFlags(transitionCmd)
+	addIssueFilterFlags(transitionCmd, jira.StatusAll)
+	_ = transitionCmd.MarkFlagRequired("to")
+}
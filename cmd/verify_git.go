@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"jira-release-manager/internal/gitlog"
+	"jira-release-manager/internal/jira"
+	"jira-release-manager/internal/organizer"
+	"jira-release-manager/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var verifyGitCmd = &cobra.Command{
+	Use:   "verify-git",
+	Short: "Confronta i ticket di una versione con i commit di un repository git.",
+	Long: `Legge i commit di un repository git locale tra due ref, estrae le chiavi dei ticket
+dai messaggi di commit e dai nomi dei branch, e le confronta con i ticket della versione:
+- ticket presenti sia in Jira che in git
+- ticket della versione senza alcun commit (gli Epic sono esclusi)
+- ticket citati nei commit ma non assegnati alla versione`,
+	Example: `  jira-release-manager verify-git -p PROJ --next --from v2.3.0
+  jira-release-manager verify-git -p PROJ --version 2.4.0 --repo ../backend --from v2.3.0 --to release/2.4
+  jira-release-manager verify-git -p PROJ --next --from v2.3.0 --strict`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		repoPath, _ := cmd.Flags().GetString("repo")
+		fromRef, _ := cmd.Flags().GetString("from")
+		toRef, _ := cmd.Flags().GetString("to")
+		strict, _ := cmd.Flags().GetBool("strict")
+
+		filter, err := issueFilterFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		// Il repository viene letto prima di interrogare Jira, per fallire subito su ref errati
+		fmt.Fprintf(os.Stderr, "⏳ Lettura commit %s..%s in %s...", fromRef, toRef, repoPath)
+		commits, err := gitlog.Commits(ctx, repoPath, fromRef, toRef)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, " ❌")
+			return err
+		}
+		gitKeys := gitlog.NewKeyMatcher(projectKey).CommitKeys(commits)
+		fmt.Fprintf(os.Stderr, " ✓ (%d commit, %d ticket citati)\n", len(commits), len(gitKeys))
+
+		version, err := resolveJiraVersion(cmd, jiraClient, projectKey)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "✅ Verifica della versione: %s\n", version.Name)
+
+		issues, err := jira.GetIssuesForVersion(ctx, jiraClient, projectKey, version.Name, filter)
+		if err != nil {
+			return fmt.Errorf("errore nel recupero dei ticket: %w", err)
+		}
+
+		result := output.GitVerification{
+			Version:        output.NewVersion(*version),
+			Repository:     repoPath,
+			From:           fromRef,
+			To:             toRef,
+			InJiraAndGit:   []output.GitIssue{},
+			MissingFromGit: []output.Issue{},
+			NotInVersion:   []output.GitIssue{},
+		}
+
+		inVersion := make(map[string]bool)
+		sortIssuesByKey(issues)
+		for _, issue := range issues {
+			inVersion[issue.Key] = true
+			if commits, ok := gitKeys[issue.Key]; ok {
+				result.InJiraAndGit = append(result.InJiraAndGit, newGitIssue(output.NewIssue(issue, jiraClient.BaseURL), commits))
+			} else if !strings.EqualFold(issue.Fields.IssueType.Name, "epic") {
+				result.MissingFromGit = append(result.MissingFromGit, output.NewIssue(issue, jiraClient.BaseURL))
+			}
+		}
+
+		var extraKeys []string
+		for key := range gitKeys {
+			if !inVersion[key] {
+				extraKeys = append(extraKeys, key)
+			}
+		}
+		organizer.SortIssueKeys(extraKeys)
+
+		if len(extraKeys) > 0 {
+			fmt.Fprint(os.Stderr, "⏳ Recupero ticket citati nei commit ma non nella versione...")
+			found, failures := jira.GetIssues(ctx, jiraClient, extraKeys)
+			fmt.Fprintln(os.Stderr, " ✓")
+
+			foundByKey := make(map[string]jira.Issue)
+			for _, issue := range found {
+				foundByKey[issue.Key] = issue
+			}
+			failureByKey := make(map[string]error)
+			for _, failure := range failures {
+				failureByKey[failure.Key] = failure.Err
+			}
+
+			for _, key := range extraKeys {
+				gitIssue := newGitIssue(output.Issue{Key: key, URL: fmt.Sprintf("%s/browse/%s", jiraClient.BaseURL, key)}, gitKeys[key])
+				if issue, ok := foundByKey[key]; ok {
+					gitIssue.Issue = output.NewIssue(issue, jiraClient.BaseURL)
+				} else if err, ok := failureByKey[key]; ok {
+					gitIssue.Error = err.Error()
+				}
+				result.NotInVersion = append(result.NotInVersion, gitIssue)
+			}
+		}
+		fmt.Fprintln(os.Stderr)
+
+		if outputFormat.IsStructured() {
+			if err := output.Write(os.Stdout, outputFormat, result); err != nil {
+				return err
+			}
+		} else {
+			printGitVerification(result)
+		}
+
+		if strict && (len(result.MissingFromGit) > 0 || len(result.NotInVersion) > 0) {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("verifica fallita: %d ticket senza commit, %d ticket nei commit ma non nella versione",
+				len(result.MissingFromGit), len(result.NotInVersion))
+		}
+		return nil
+	},
+}
+
+// newGitIssue associa a un ticket gli hash abbreviati dei commit che lo citano
+func newGitIssue(issue output.Issue, commits []gitlog.Commit) output.GitIssue {
+	hashes := make([]string, 0, len(commits))
+	for _, commit := range commits {
+		hashes = append(hashes, commit.ShortHash())
+	}
+	return output.GitIssue{Issue: issue, Commits: hashes}
+}
+
+// sortIssuesByKey ordina i ticket per chiave (PROJ-9 prima di PROJ-10)
+func sortIssuesByKey(issues []jira.Issue) {
+	keys := make([]string, len(issues))
+	for i, issue := range issues {
+		keys[i] = issue.Key
+	}
+	organizer.SortIssueKeys(keys)
+
+	position := make(map[string]int, len(keys))
+	for i, key := range keys {
+		position[key] = i
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		return position[issues[i].Key] < position[issues[j].Key]
+	})
+}
+
+// printGitVerification stampa il risultato del confronto in formato leggibile
+func printGitVerification(result output.GitVerification) {
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	fmt.Printf("  VERSIONE '%s' ↔ GIT %s..%s\n", result.Version.Name, result.From, result.To)
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
+
+	fmt.Printf("✅ IN JIRA E IN GIT (%d)\n", len(result.InJiraAndGit))
+	fmt.Println(strings.Repeat("─", 80))
+	for _, issue := range result.InJiraAndGit {
+		fmt.Printf("  [%s] %s\n", issue.Key, issue.Summary)
+		fmt.Printf("      commit: %s\n", strings.Join(issue.Commits, ", "))
+	}
+	fmt.Println()
+
+	fmt.Printf("❌ IN JIRA MA NON IN GIT (%d)\n", len(result.MissingFromGit))
+	fmt.Println(strings.Repeat("─", 80))
+	for _, issue := range result.MissingFromGit {
+		fmt.Printf("  [%s] %s (%s)\n", issue.Key, issue.Summary, issue.Status)
+	}
+	fmt.Println()
+
+	fmt.Printf("⚠️  IN GIT MA NON NELLA VERSIONE (%d)\n", len(result.NotInVersion))
+	fmt.Println(strings.Repeat("─", 80))
+	for _, issue := range result.NotInVersion {
+		switch {
+		case issue.Error != "":
+			fmt.Printf("  [%s] ticket non recuperato: %s\n", issue.Key, issue.Error)
+		case len(issue.FixVersions) == 0:
+			fmt.Printf("  [%s] %s (nessuna fixVersion)\n", issue.Key, issue.Summary)
+		default:
+			fmt.Printf("  [%s] %s (fixVersion: %s)\n", issue.Key, issue.Summary, strings.Join(issue.FixVersions, ", "))
+		}
+		fmt.Printf("      commit: %s\n", strings.Join(issue.Commits, ", "))
+	}
+	fmt.Println()
+
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	fmt.Printf("  TOTALE: %d verificati, %d senza commit, %d fuori versione\n",
+		len(result.InJiraAndGit), len(result.MissingFromGit), len(result.NotInVersion))
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+}
+
+func init() {
+	rootCmd.AddCommand(verifyGitCmd)
+	verifyGitCmd.Flags().String("repo", ".", "Percorso del repository git locale")
+	verifyGitCmd.Flags().String("from", "", "Ref di partenza, esclusa (es. il tag della release precedente)")
+	verifyGitCmd.Flags().String("to", "HEAD", "Ref di arrivo, inclusa")
+	verifyGitCmd.Flags().Bool("strict", false, "Termina con errore se ci sono ticket senza commit o fuori versione")
+	_ = verifyGitCmd.MarkFlagRequired("from")
+	addVersionFlags(verifyGitCmd)
+	addIssueFilterFlags(verifyGitCmd, jira.StatusAll)
+}
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"jira-release-manager/internal/jira"
+	"jira-release-manager/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Crea, rilascia, archivia e modifica le versioni Jira.",
+	Long: `Raccoglie i comandi per gestire il ciclo di vita di una versione Jira
+direttamente da terminale, dalla creazione fino al rilascio e all'archiviazione.`,
+}
+
+var versionCreateCmd = &cobra.Command{
+	Use:   "create <nome>",
+	Short: "Crea una nuova versione nel progetto.",
+	Example: `  jira-release-manager version create 2.5.0 -p PROJ
+  jira-release-manager version create 2.5.0 -p PROJ --release-date 2026-11-30 --description "Release di novembre"`,
+	Args: cobra.ExactArgs(1),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		description, _ := cmd.Flags().GetString("description")
+		startDate, _ := cmd.Flags().GetString("start-date")
+		releaseDate, _ := cmd.Flags().GetString("release-date")
+
+		if err := validateDates(startDate, releaseDate); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "⏳ Creazione versione %s nel progetto %s...\n", args[0], projectKey)
+		version, err := jira.CreateVersion(ctx, jiraClient, projectKey, jira.VersionCreate{
+			Name:        args[0],
+			Description: description,
+			StartDate:   startDate,
+			ReleaseDate: releaseDate,
+		})
+		if err != nil {
+			return err
+		}
+
+		return printVersionResult(version, "✅ Versione %s creata (ID: %s)\n")
+	},
+}
+
+var versionReleaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Segna una versione come rilasciata.",
+	Long: `Segna la versione selezionata come rilasciata. Con --move-unresolved-to i ticket
+non risolti vengono spostati su un'altra versione, come avviene dalla UI di Jira.`,
+	Example: `  jira-release-manager version release -p PROJ --next
+  jira-release-manager version release -p PROJ --version 2.4.0 --release-date 2026-10-12
+  jira-release-manager version release -p PROJ --next --move-unresolved-to 2.5.0`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		releaseDate, _ := cmd.Flags().GetString("release-date")
+		moveTo, _ := cmd.Flags().GetString("move-unresolved-to")
+
+		if releaseDate == "" {
+			releaseDate = time.Now().Format("2006-01-02")
+		}
+		if err := validateDates(releaseDate); err != nil {
+			return err
+		}
+
+		version, err := resolveJiraVersion(cmd, jiraClient, projectKey)
+		if err != nil {
+			return err
+		}
+		if version.Released {
+			return fmt.Errorf("la versione %s è già rilasciata", version.Name)
+		}
+
+		var target *jira.Version
+		if moveTo != "" {
+			versions, err := jira.GetAllProjectVersions(ctx, jiraClient, projectKey)
+			if err != nil {
+				return fmt.Errorf("errore nel recupero delle versioni: %w", err)
+			}
+			target, err = matchVersion(versions, moveTo)
+			if err != nil {
+				return err
+			}
+			if target.ID == version.ID {
+				return fmt.Errorf("la versione di destinazione deve essere diversa da %s", version.Name)
+			}
+			fmt.Fprintf(os.Stderr, "ℹ️  I ticket non risolti verranno spostati su %s\n", target.Name)
+		}
+
+		fmt.Fprintf(os.Stderr, "⏳ Rilascio versione %s...\n", version.Name)
+		released, err := jira.ReleaseVersion(ctx, jiraClient, version, releaseDate, target)
+		if err != nil {
+			return err
+		}
+
+		return printVersionResult(released, "✅ Versione %s rilasciata (ID: %s)\n")
+	},
+}
+
+var versionArchiveCmd = &cobra.Command{
+	Use:     "archive",
+	Short:   "Archivia una versione.",
+	Example: `  jira-release-manager version archive -p PROJ --version 2.3.0`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		version, err := resolveJiraVersion(cmd, jiraClient, projectKey)
+		if err != nil {
+			return err
+		}
+		if version.Archived {
+			return fmt.Errorf("la versione %s è già archiviata", version.Name)
+		}
+
+		fmt.Fprintf(os.Stderr, "⏳ Archiviazione versione %s...\n", version.Name)
+		archived, err := jira.ArchiveVersion(ctx, jiraClient, version)
+		if err != nil {
+			return err
+		}
+
+		return printVersionResult(archived, "✅ Versione %s archiviata (ID: %s)\n")
+	},
+}
+
+var versionUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Modifica nome, descrizione o date di una versione.",
+	Example: `  jira-release-manager version update -p PROJ --next --release-date 2026-11-30
+  jira-release-manager version update -p PROJ --version 2.4.0 --description "Hotfix pagamenti"`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		var data jira.VersionUpdate
+		changed := false
+
+		if cmd.Flags().Changed("name") {
+			data.Name, _ = cmd.Flags().GetString("name")
+			changed = true
+		}
+		if cmd.Flags().Changed("description") {
+			description, _ := cmd.Flags().GetString("description")
+			data.Description = &description
+			changed = true
+		}
+		if cmd.Flags().Changed("start-date") {
+			data.StartDate, _ = cmd.Flags().GetString("start-date")
+			changed = true
+		}
+		if cmd.Flags().Changed("release-date") {
+			data.ReleaseDate, _ = cmd.Flags().GetString("release-date")
+			changed = true
+		}
+
+		if !changed {
+			return fmt.Errorf("specificare almeno uno tra --name, --description, --start-date, --release-date")
+		}
+		if err := validateDates(data.StartDate, data.ReleaseDate); err != nil {
+			return err
+		}
+
+		version, err := resolveJiraVersion(cmd, jiraClient, projectKey)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "⏳ Aggiornamento versione %s...\n", version.Name)
+		updated, err := jira.UpdateVersion(ctx, jiraClient, version.ID, data)
+		if err != nil {
+			return err
+		}
+
+		return printVersionResult(updated, "✅ Versione %s aggiornata (ID: %s)\n")
+	},
+}
+
+// validateDates verifica che le date non vuote siano nel formato YYYY-MM-DD
+func validateDates(dates ...string) error {
+	for _, date := range dates {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return fmt.Errorf("data non valida %q: usare il formato YYYY-MM-DD", date)
+		}
+	}
+	return nil
+}
+
+// printVersionResult stampa la versione risultante nel formato di output richiesto
+func printVersionResult(version *jira.Version, message string) error {
+	if outputFormat.IsStructured() {
+		return output.Write(os.Stdout, outputFormat, output.NewVersion(*version))
+	}
+	fmt.Printf(message, version.Name, version.ID)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+	versionCmd.AddCommand(versionCreateCmd, versionReleaseCmd, versionArchiveCmd, versionUpdateCmd)
+
+	versionCreateCmd.Flags().String("description", "", "Descrizione della versione")
+	versionCreateCmd.Flags().String("start-date", "", "Data di inizio (YYYY-MM-DD)")
+	versionCreateCmd.Flags().String("release-date", "", "Data di rilascio prevista (YYYY-MM-DD)")
+
+	versionReleaseCmd.Flags().String("release-date", "", "Data di rilascio (YYYY-MM-DD, default: oggi)")
+	versionReleaseCmd.Flags().String("move-unresolved-to", "", "Sposta i ticket non risolti sulla versione indicata (stessa sintassi di --version)")
+	addVersionFlags(versionReleaseCmd)
+
+	addVersionFlags(versionArchiveCmd)
+
+	versionUpdateCmd.Flags().String("name", "", "Nuovo nome della versione")
+	versionUpdateCmd.Flags().String("description", "", "Nuova descrizione della versione")
+	versionUpdateCmd.Flags().String("start-date", "", "Nuova data di inizio (YYYY-MM-DD)")
+	versionUpdateCmd.Flags().String("release-date", "", "Nuova data di rilascio (YYYY-MM-DD)")
+	addVersionFlags(versionUpdateCmd)
+}
@@ -0,0 +1,276 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"jira-release-manager/internal/jira"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// appName è il nome della cartella di configurazione
+const appName = "jira-release-manager"
+
+// File rappresenta il file di configurazione con i profili per più istanze Jira
+type File struct {
+	DefaultProfile string             `yaml:"default_profile,omitempty"`
+	Profiles       map[string]Profile `yaml:"profiles"`
+}
+
+// Profile contiene la configurazione di un'istanza Jira. I campi vuoti non vengono applicati.
+type Profile struct {
+	URL          string   `yaml:"url,omitempty"`
+	Deployment   string   `yaml:"deployment,omitempty"`
+	AuthType     string   `yaml:"auth_type,omitempty"`
+	Username     string   `yaml:"username,omitempty"`
+	APIToken     string   `yaml:"api_token,omitempty"` // Sconsigliato: preferire la variabile d'ambiente
+	Project      string   `yaml:"project,omitempty"`
+	Fields       Fields   `yaml:"fields,omitempty"`
+	SubtaskTypes []string `yaml:"subtask_types,omitempty"`
+	Timeout      string   `yaml:"timeout,omitempty"`
+	MaxRetries   *int     `yaml:"max_retries,omitempty"`
+	Concurrency  *int     `yaml:"concurrency,omitempty"`
+
+	Changelog Changelog `yaml:"changelog,omitempty"`
+	Publish   Publish   `yaml:"publish,omitempty"`
+	Readiness Readiness `yaml:"readiness,omitempty"`
+}
+
+// Fields contiene gli ID dei campi personalizzati dell'istanza
+type Fields struct {
+	EpicLink string `yaml:"epic_link,omitempty"`
+}
+
+// Changelog contiene i valori predefiniti dei flag di rendering
+type Changelog struct {
+	Format          string `yaml:"format,omitempty"`
+	Template        string `yaml:"template,omitempty"`
+	IncludeSubtasks *bool  `yaml:"include_subtasks,omitempty"`
+	TypeMapping     string `yaml:"type_mapping,omitempty"`
+}
+
+// Publish contiene le destinazioni predefinite dei comandi publish
+type Publish struct {
+	TeamsWebhookURL        string `yaml:"teams_webhook_url,omitempty"`
+	SlackWebhookURL        string `yaml:"slack_webhook_url,omitempty"`
+	ConfluenceURL          string `yaml:"confluence_url,omitempty"`
+	ConfluenceJiraServerID string `yaml:"confluence_jira_server_id,omitempty"`
+	GitHubAPIURL           string `yaml:"github_api_url,omitempty"`
+	GitLabAPIURL           string `yaml:"gitlab_api_url,omitempty"`
+}
+
+// Readiness contiene i valori predefiniti delle regole del comando readiness
+type Readiness struct {
+	Rules           string   `yaml:"rules,omitempty"` // Livelli delle regole, es. "labels=off,unassigned=fail"
+	BlockedStatuses []string `yaml:"blocked_statuses,omitempty"`
+	HighPriority    string   `yaml:"high_priority,omitempty"`
+	MaxBugPriority  string   `yaml:"max_bug_priority,omitempty"`
+	BugTypes        []string `yaml:"bug_types,omitempty"`
+	RepoLabels      []string `yaml:"repo_labels,omitempty"`
+}
+
+// DefaultPath restituisce il percorso predefinito del file di configurazione:
+// $XDG_CONFIG_HOME/jira-release-manager/config.yaml, o ~/.config/jira-release-manager/config.yaml
+func DefaultPath() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, appName, "config.yaml"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("impossibile determinare la cartella home: %w", err)
+	}
+	return filepath.Join(home, ".config", appName, "config.yaml"), nil
+}
+
+// Load legge e decodifica il file di configurazione. I campi sconosciuti sono considerati errori,
+// così un refuso non viene ignorato in silenzio.
+func Load(path string) (*File, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("impossibile leggere il file di configurazione %s: %w", path, err)
+	}
+
+	file := &File{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("file di configurazione %s non valido: %w", path, err)
+	}
+	if file.Profiles == nil {
+		file.Profiles = make(map[string]Profile)
+	}
+	return file, nil
+}
+
+// Save scrive il file di configurazione, creando la cartella se necessario.
+// Il file è leggibile solo dall'utente, perché può contenere credenziali.
+func (f *File) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("impossibile creare la cartella %s: %w", filepath.Dir(path), err)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(f); err != nil {
+		return fmt.Errorf("errore nella serializzazione della configurazione: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("impossibile scrivere il file di configurazione %s: %w", path, err)
+	}
+	return nil
+}
+
+// ProfileNames restituisce i nomi dei profili in ordine alfabetico
+func (f *File) ProfileNames() []string {
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Resolve restituisce il profilo richiesto o, se name è vuoto, quello predefinito.
+// Se il file ha un solo profilo viene usato quello. Restituisce nil se non c'è un profilo da applicare.
+func (f *File) Resolve(name string) (*Profile, string, error) {
+	if name == "" {
+		name = f.DefaultProfile
+	}
+	if name == "" && len(f.Profiles) == 1 {
+		name = f.ProfileNames()[0]
+	}
+	if name == "" {
+		return nil, "", nil
+	}
+
+	profile, ok := f.Profiles[name]
+	if !ok {
+		return nil, "", fmt.Errorf("profilo '%s' non trovato (disponibili: %s)", name, strings.Join(f.ProfileNames(), ", "))
+	}
+	return &profile, name, nil
+}
+
+// Settings converte il profilo nelle stesse chiavi delle variabili d'ambiente (JIRA_URL, ...)
+func (p *Profile) Settings() map[string]string {
+	settings := map[string]string{
+		"JIRA_URL":                  p.URL,
+		"JIRA_DEPLOYMENT":           p.Deployment,
+		"JIRA_AUTH_TYPE":            p.AuthType,
+		"JIRA_USERNAME":             p.Username,
+		"JIRA_API_TOKEN":            p.APIToken,
+		"DEFAULT_PROJECT":           p.Project,
+		"JIRA_EPIC_LINK_FIELD":      p.Fields.EpicLink,
+		"JIRA_SUBTASK_TYPES":        strings.Join(p.SubtaskTypes, ","),
+		"JIRA_TIMEOUT":              p.Timeout,
+		"CHANGELOG_TYPE_MAPPING":    p.Changelog.TypeMapping,
+		"TEAMS_WEBHOOK_URL":         p.Publish.TeamsWebhookURL,
+		"SLACK_WEBHOOK_URL":         p.Publish.SlackWebhookURL,
+		"CONFLUENCE_URL":            p.Publish.ConfluenceURL,
+		"CONFLUENCE_JIRA_SERVER_ID": p.Publish.ConfluenceJiraServerID,
+		"GITHUB_API_URL":            p.Publish.GitHubAPIURL,
+		"GITLAB_API_URL":            p.Publish.GitLabAPIURL,
+	}
+	if p.MaxRetries != nil {
+		settings["JIRA_MAX_RETRIES"] = strconv.Itoa(*p.MaxRetries)
+	}
+	if p.Concurrency != nil {
+		settings["JIRA_CONCURRENCY"] = strconv.Itoa(*p.Concurrency)
+	}
+
+	for key, value := range settings {
+		if value == "" {
+			delete(settings, key)
+		}
+	}
+	return settings
+}
+
+// FlagDefaults restituisce i valori predefiniti dei flag di rendering e di readiness, indicizzati per nome del flag
+func (p *Profile) FlagDefaults() map[string]string {
+	defaults := make(map[string]string)
+	if p.Changelog.Format != "" {
+		defaults["format"] = p.Changelog.Format
+	}
+	if p.Changelog.Template != "" {
+		defaults["template"] = expandHome(p.Changelog.Template)
+	}
+	if p.Changelog.IncludeSubtasks != nil {
+		defaults["include-subtasks"] = strconv.FormatBool(*p.Changelog.IncludeSubtasks)
+	}
+
+	readiness := map[string]string{
+		"rules":            p.Readiness.Rules,
+		"blocked-statuses": strings.Join(p.Readiness.BlockedStatuses, ","),
+		"high-priority":    p.Readiness.HighPriority,
+		"max-bug-priority": p.Readiness.MaxBugPriority,
+		"bug-types":        strings.Join(p.Readiness.BugTypes, ","),
+		"repo-labels":      strings.Join(p.Readiness.RepoLabels, ","),
+	}
+	for name, value := range readiness {
+		if value != "" {
+			defaults[name] = value
+		}
+	}
+	return defaults
+}
+
+// Validate controlla i valori di un profilo e restituisce tutti i problemi trovati
+func (p *Profile) Validate() []error {
+	var problems []error
+
+	if p.URL == "" {
+		problems = append(problems, fmt.Errorf("url mancante"))
+	} else if u, err := url.Parse(p.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		problems = append(problems, fmt.Errorf("url non valido: %s", p.URL))
+	}
+	deployment, err := jira.ParseDeployment(p.Deployment)
+	if err != nil {
+		problems = append(problems, err)
+	}
+	if _, err := jira.ParseAuthType(p.AuthType, deployment); err != nil {
+		problems = append(problems, err)
+	}
+	if p.Username == "" && deployment != jira.DeploymentServer && p.AuthType != string(jira.AuthBearer) {
+		problems = append(problems, fmt.Errorf("username mancante (necessario con l'autenticazione basic)"))
+	}
+	if p.Timeout != "" {
+		if _, err := time.ParseDuration(p.Timeout); err != nil {
+			problems = append(problems, fmt.Errorf("timeout non valido: %s", p.Timeout))
+		}
+	}
+	if p.MaxRetries != nil && *p.MaxRetries < 0 {
+		problems = append(problems, fmt.Errorf("max_retries non può essere negativo"))
+	}
+	if p.Concurrency != nil && *p.Concurrency < 1 {
+		problems = append(problems, fmt.Errorf("concurrency deve essere almeno 1"))
+	}
+	if p.Changelog.Template != "" {
+		if _, err := os.Stat(expandHome(p.Changelog.Template)); err != nil {
+			problems = append(problems, fmt.Errorf("template non trovato: %s", p.Changelog.Template))
+		}
+	}
+	return problems
+}
+
+// expandHome espande il prefisso ~/ nei percorsi
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
@@ -0,0 +1,170 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeConfig scrive il contenuto in un file di configurazione temporaneo
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeConfig(t, `
+default_profile: cloud
+profiles:
+  cloud:
+    url: https://example.atlassian.net
+    username: mario@example.com
+    max_retries: 5
+  server:
+    url: https://jira.example.com
+    deployment: server
+`)
+	file, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.DefaultProfile != "cloud" || !reflect.DeepEqual(file.ProfileNames(), []string{"cloud", "server"}) {
+		t.Fatalf("file letto: %+v", file)
+	}
+	if retries := file.Profiles["cloud"].MaxRetries; retries == nil || *retries != 5 {
+		t.Errorf("max_retries = %v, atteso 5", retries)
+	}
+
+	// Un file vuoto è valido e non ha profili
+	file, err = Load(writeConfig(t, ""))
+	if err != nil || file.Profiles == nil || len(file.Profiles) != 0 {
+		t.Errorf("file vuoto: %+v, errore %v", file, err)
+	}
+}
+
+func TestLoadUnknownField(t *testing.T) {
+	path := writeConfig(t, `
+profiles:
+  cloud:
+    url: https://example.atlassian.net
+    usernmae: mario@example.com
+`)
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "usernmae") {
+		t.Errorf("campo sconosciuto: errore atteso, ottenuto %v", err)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	single := &File{Profiles: map[string]Profile{"work": {URL: "https://work"}}}
+	multiple := &File{Profiles: map[string]Profile{"work": {URL: "https://work"}, "home": {URL: "https://home"}}}
+	withDefault := &File{DefaultProfile: "home", Profiles: multiple.Profiles}
+
+	tests := []struct {
+		name     string
+		file     *File
+		profile  string
+		wantName string
+		wantErr  bool
+	}{
+		{"profilo esplicito", withDefault, "work", "work", false},
+		{"profilo predefinito", withDefault, "", "home", false},
+		{"unico profilo", single, "", "work", false},
+		{"più profili senza predefinito", multiple, "", "", false},
+		{"profilo inesistente", withDefault, "altro", "", true},
+	}
+	for _, tt := range tests {
+		profile, name, err := tt.file.Resolve(tt.profile)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: errore %v", tt.name, err)
+			continue
+		}
+		if name != tt.wantName || (name == "") != (profile == nil) {
+			t.Errorf("%s: profilo %q (%v), atteso %q", tt.name, name, profile, tt.wantName)
+		}
+		if profile != nil && profile.URL != "https://"+name {
+			t.Errorf("%s: url %q", tt.name, profile.URL)
+		}
+	}
+}
+
+func TestSettings(t *testing.T) {
+	retries, concurrency := 0, 4
+	profile := &Profile{
+		URL:          "https://jira.example.com",
+		Deployment:   "server",
+		SubtaskTypes: []string{"Sub-task", "Technical task"},
+		Fields:       Fields{EpicLink: "customfield_10008"},
+		MaxRetries:   &retries,
+		Concurrency:  &concurrency,
+		Publish:      Publish{SlackWebhookURL: "https://hooks.slack.com/x"},
+	}
+	want := map[string]string{
+		"JIRA_URL":             "https://jira.example.com",
+		"JIRA_DEPLOYMENT":      "server",
+		"JIRA_SUBTASK_TYPES":   "Sub-task,Technical task",
+		"JIRA_EPIC_LINK_FIELD": "customfield_10008",
+		"JIRA_MAX_RETRIES":     "0",
+		"JIRA_CONCURRENCY":     "4",
+		"SLACK_WEBHOOK_URL":    "https://hooks.slack.com/x",
+	}
+	if got := profile.Settings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Settings = %v, attese %v", got, want)
+	}
+}
+
+func TestFlagDefaults(t *testing.T) {
+	t.Setenv("HOME", "/home/mario")
+	includeSubtasks := false
+	profile := &Profile{
+		Changelog: Changelog{Format: "markdown", Template: "~/templates/release.tmpl", IncludeSubtasks: &includeSubtasks},
+		Readiness: Readiness{Rules: "labels=off", BugTypes: []string{"Bug", "Defect"}},
+	}
+	want := map[string]string{
+		"format":           "markdown",
+		"template":         "/home/mario/templates/release.tmpl",
+		"include-subtasks": "false",
+		"rules":            "labels=off",
+		"bug-types":        "Bug,Defect",
+	}
+	if got := profile.FlagDefaults(); !reflect.DeepEqual(got, want) {
+		t.Errorf("FlagDefaults = %v, attesi %v", got, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	negative, zero := -1, 0
+	tests := []struct {
+		name    string
+		profile Profile
+		want    []string
+	}{
+		{"profilo cloud valido", Profile{URL: "https://example.atlassian.net", Username: "mario"}, nil},
+		{"server con PAT senza username", Profile{URL: "https://jira.example.com", Deployment: "server"}, nil},
+		{"url mancante", Profile{Username: "mario"}, []string{"url mancante"}},
+		{"url senza schema", Profile{URL: "jira.example.com", Username: "mario"}, []string{"url non valido"}},
+		{"cloud senza username", Profile{URL: "https://example.atlassian.net"}, []string{"username mancante"}},
+		{
+			"più errori",
+			Profile{URL: "https://x", Username: "mario", Deployment: "onprem", Timeout: "30", MaxRetries: &negative, Concurrency: &zero, Changelog: Changelog{Template: "/non/esiste.tmpl"}},
+			[]string{"installazione Jira non valido", "timeout non valido", "max_retries", "concurrency", "template non trovato"},
+		},
+	}
+	for _, tt := range tests {
+		problems := tt.profile.Validate()
+		if len(problems) != len(tt.want) {
+			t.Errorf("%s: problemi %v, attesi %v", tt.name, problems, tt.want)
+			continue
+		}
+		for i, problem := range problems {
+			if !strings.Contains(problem.Error(), tt.want[i]) {
+				t.Errorf("%s: problema %q, atteso %q", tt.name, problem, tt.want[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package credentials
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+)
+
+// service è il nome con cui i token vengono salvati nel portachiavi di sistema
+const service = "jira-release-manager"
+
+// ErrNotFound indica che non esiste un token salvato per l'account
+var ErrNotFound = errors.New("nessun token salvato")
+
+// Backend identifica dove viene salvato il token
+type Backend string
+
+const (
+	// BackendKeyring è il portachiavi di sistema (Secret Service su Linux, Keychain su macOS, Credential Manager su Windows)
+	BackendKeyring Backend = "keyring"
+	// BackendFile è il file cifrato, per ambienti senza portachiavi (es. CI headless)
+	BackendFile Backend = "file"
+)
+
+// ParseBackend valida il valore di JIRA_CREDENTIAL_STORE; se vuoto il backend viene scelto automaticamente
+func ParseBackend(value string) (Backend, error) {
+	switch b := Backend(strings.ToLower(strings.TrimSpace(value))); b {
+	case "", BackendKeyring, BackendFile:
+		return b, nil
+	default:
+		return "", fmt.Errorf("archivio credenziali non valido: %q (valori ammessi: keyring, file)", value)
+	}
+}
+
+// Account restituisce la chiave con cui salvare il token di un utente su un'istanza Jira
+func Account(jiraURL, username string) string {
+	jiraURL = strings.TrimSuffix(jiraURL, "/")
+	if username == "" {
+		return jiraURL
+	}
+	return username + "@" + jiraURL
+}
+
+// Lookup cerca il token di un account, prima nel portachiavi e poi nel file cifrato.
+// Restituisce il token e il backend da cui proviene, o ErrNotFound.
+func Lookup(account string, backend Backend) (string, Backend, error) {
+	if backend != BackendFile {
+		token, err := keyring.Get(service, account)
+		if err == nil {
+			return token, BackendKeyring, nil
+		}
+		if backend == BackendKeyring {
+			return "", "", keyringError(err)
+		}
+	}
+
+	// Senza passphrase il file cifrato non è utilizzabile: il token semplicemente non c'è
+	if backend == "" && os.Getenv(passphraseEnv) == "" {
+		return "", "", ErrNotFound
+	}
+	token, err := fileGet(account)
+	if err != nil {
+		return "", "", err
+	}
+	return token, BackendFile, nil
+}
+
+// Save salva il token di un account. Senza un backend esplicito usa il portachiavi e,
+// se non è disponibile, ricade sul file cifrato. Restituisce il backend usato.
+func Save(account, token string, backend Backend) (Backend, error) {
+	if backend != BackendFile {
+		err := keyring.Set(service, account, token)
+		if err == nil {
+			return BackendKeyring, nil
+		}
+		if backend == BackendKeyring {
+			return "", fmt.Errorf("impossibile salvare il token nel portachiavi: %w", err)
+		}
+		if os.Getenv(passphraseEnv) == "" {
+			return "", fmt.Errorf("portachiavi di sistema non disponibile (%v): imposta %s per usare il file cifrato", err, passphraseEnv)
+		}
+	}
+
+	if err := fileSet(account, token); err != nil {
+		return "", err
+	}
+	return BackendFile, nil
+}
+
+// Delete rimuove il token di un account da tutti gli archivi in cui è presente.
+// Restituisce i backend da cui è stato rimosso.
+func Delete(account string) ([]Backend, error) {
+	var removed []Backend
+	if err := keyring.Delete(service, account); err == nil {
+		removed = append(removed, BackendKeyring)
+	}
+
+	if os.Getenv(passphraseEnv) != "" {
+		deleted, err := fileDelete(account)
+		if err != nil {
+			return removed, err
+		}
+		if deleted {
+			removed = append(removed, BackendFile)
+		}
+	}
+	return removed, nil
+}
+
+// keyringError converte l'errore del portachiavi, distinguendo l'assenza del token
+func keyringError(err error) error {
+	if errors.Is(err, keyring.ErrNotFound) {
+		return ErrNotFound
+	}
+	return fmt.Errorf("portachiavi di sistema non disponibile: %w", err)
+}
@@ -0,0 +1,103 @@
+package credentials
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+const account = "mario@https://jira.example.com"
+
+// useTempFile fa puntare il file cifrato a una cartella temporanea, con la passphrase indicata
+func useTempFile(t *testing.T, secret string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "credentials.enc")
+	t.Setenv("JIRA_CREDENTIALS_FILE", path)
+	t.Setenv(passphraseEnv, secret)
+	return path
+}
+
+func TestFileRoundTrip(t *testing.T) {
+	keyring.MockInit()
+	path := useTempFile(t, "segreta")
+
+	used, err := Save(account, "token-1", BackendFile)
+	if err != nil || used != BackendFile {
+		t.Fatalf("Save: backend=%s err=%v", used, err)
+	}
+	if _, err := Save("altro@https://jira.example.com", "token-2", BackendFile); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(content, []byte("token-1")) {
+		t.Fatalf("il token è salvato in chiaro: %s", content)
+	}
+
+	token, from, err := Lookup(account, BackendFile)
+	if err != nil || token != "token-1" || from != BackendFile {
+		t.Fatalf("Lookup: token=%q backend=%s err=%v", token, from, err)
+	}
+
+	// Senza backend esplicito il portachiavi vuoto ricade sul file
+	if token, from, err = Lookup(account, ""); err != nil || token != "token-1" || from != BackendFile {
+		t.Fatalf("Lookup automatico: token=%q backend=%s err=%v", token, from, err)
+	}
+}
+
+func TestFileWrongPassphrase(t *testing.T) {
+	keyring.MockInit()
+	useTempFile(t, "segreta")
+	if _, err := Save(account, "token-1", BackendFile); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(passphraseEnv, "sbagliata")
+	if _, _, err := Lookup(account, BackendFile); err == nil || errors.Is(err, ErrNotFound) {
+		t.Fatalf("Lookup con passphrase errata: errore di decifratura atteso, ottenuto %v", err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	keyring.MockInit()
+	useTempFile(t, "segreta")
+	if _, err := Save(account, "token-keyring", BackendKeyring); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Save(account, "token-file", BackendFile); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := Delete(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(removed, []Backend{BackendKeyring, BackendFile}) {
+		t.Errorf("rimosso da %v, attesi keyring e file", removed)
+	}
+	if _, _, err := Lookup(account, ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Lookup dopo Delete: atteso ErrNotFound, ottenuto %v", err)
+	}
+}
+
+func TestSaveFallsBackToFile(t *testing.T) {
+	keyring.MockInitWithError(errors.New("portachiavi non disponibile"))
+	t.Cleanup(keyring.MockInit)
+	useTempFile(t, "segreta")
+
+	used, err := Save(account, "token-1", "")
+	if err != nil || used != BackendFile {
+		t.Fatalf("Save: backend=%s err=%v", used, err)
+	}
+	if _, err := Save(account, "token-1", BackendKeyring); err == nil {
+		t.Error("Save nel portachiavi: errore atteso")
+	}
+}
@@ -0,0 +1,184 @@
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// passphraseEnv è la variabile con la passphrase del file cifrato
+const passphraseEnv = "JIRA_CREDENTIALS_PASSPHRASE"
+
+// Parametri della derivazione della chiave AES-256 dalla passphrase
+const (
+	keyIterations = 600000
+	keyLength     = 32
+	saltLength    = 16
+)
+
+// encryptedFile è il contenuto su disco del file cifrato
+type encryptedFile struct {
+	Salt  []byte `json:"salt"`
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"` // Mappa account → token, cifrata con AES-GCM
+}
+
+// filePath restituisce il percorso del file cifrato: JIRA_CREDENTIALS_FILE, o
+// $XDG_CONFIG_HOME/jira-release-manager/credentials.enc
+func filePath() (string, error) {
+	if path := os.Getenv("JIRA_CREDENTIALS_FILE"); path != "" {
+		return path, nil
+	}
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("impossibile determinare la cartella home: %w", err)
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, service, "credentials.enc"), nil
+}
+
+// passphrase restituisce la passphrase del file cifrato
+func passphrase() (string, error) {
+	value := os.Getenv(passphraseEnv)
+	if value == "" {
+		return "", fmt.Errorf("la variabile %s non è impostata", passphraseEnv)
+	}
+	return value, nil
+}
+
+// readTokens decifra il file e restituisce tutti i token salvati (vuoto se il file non esiste)
+func readTokens() (map[string]string, error) {
+	path, err := filePath()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := passphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return make(map[string]string), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("impossibile leggere %s: %w", path, err)
+	}
+
+	var file encryptedFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("file delle credenziali %s non valido: %w", path, err)
+	}
+	gcm, err := newGCM(secret, file.Salt)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := gcm.Open(nil, file.Nonce, file.Data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("impossibile decifrare %s: passphrase errata o file danneggiato", path)
+	}
+
+	tokens := make(map[string]string)
+	if err := json.Unmarshal(plain, &tokens); err != nil {
+		return nil, fmt.Errorf("file delle credenziali %s non valido: %w", path, err)
+	}
+	return tokens, nil
+}
+
+// writeTokens cifra e salva i token con un nuovo salt e un nuovo nonce
+func writeTokens(tokens map[string]string) error {
+	path, err := filePath()
+	if err != nil {
+		return err
+	}
+	secret, err := passphrase()
+	if err != nil {
+		return err
+	}
+
+	plain, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	gcm, err := newGCM(secret, salt)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	content, err := json.Marshal(encryptedFile{Salt: salt, Nonce: nonce, Data: gcm.Seal(nil, nonce, plain, nil)})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("impossibile creare la cartella %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		return fmt.Errorf("impossibile scrivere %s: %w", path, err)
+	}
+	return nil
+}
+
+// newGCM deriva la chiave dalla passphrase e crea il cifrario AES-GCM
+func newGCM(secret string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, secret, salt, keyIterations, keyLength)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func fileGet(account string) (string, error) {
+	tokens, err := readTokens()
+	if err != nil {
+		return "", err
+	}
+	token, ok := tokens[account]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return token, nil
+}
+
+func fileSet(account, token string) error {
+	tokens, err := readTokens()
+	if err != nil {
+		return err
+	}
+	tokens[account] = token
+	return writeTokens(tokens)
+}
+
+func fileDelete(account string) (bool, error) {
+	tokens, err := readTokens()
+	if err != nil {
+		return false, err
+	}
+	if _, ok := tokens[account]; !ok {
+		return false, nil
+	}
+	delete(tokens, account)
+	return true, writeTokens(tokens)
+}
@@ -0,0 +1,126 @@
+package gitlog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// Separatori usati nel formato di git log per distinguere campi e commit
+const (
+	fieldSep  = "\x1f"
+	commitSep = "\x1e"
+)
+
+// Commit rappresenta un commit letto dal repository
+type Commit struct {
+	Hash    string
+	Subject string
+	Body    string
+	Refs    string // Branch e tag che puntano al commit (%D)
+}
+
+// ShortHash restituisce l'hash abbreviato del commit
+func (c Commit) ShortHash() string {
+	if len(c.Hash) > 10 {
+		return c.Hash[:10]
+	}
+	return c.Hash
+}
+
+// Commits legge i commit raggiungibili da toRef ma non da fromRef (git log fromRef..toRef)
+// usando il binario git.
+func Commits(ctx context.Context, repoPath, fromRef, toRef string) ([]Commit, error) {
+	// Un ref che inizia con "-" verrebbe interpretato da git come opzione (es. --output=<file>)
+	for _, ref := range []string{fromRef, toRef} {
+		if ref == "" || strings.HasPrefix(ref, "-") {
+			return nil, fmt.Errorf("ref git non valido: %q", ref)
+		}
+	}
+
+	format := strings.Join([]string{"%H", "%s", "%b", "%D"}, fieldSep) + commitSep
+	cmd := exec.CommandContext(ctx, "git", "-C", repoPath, "log", "--format="+format, "--end-of-options", fromRef+".."+toRef)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("errore nell'esecuzione di git log %s..%s: %w: %s", fromRef, toRef, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return parseLog(stdout.String()), nil
+}
+
+// parseLog interpreta l'output di git log nel formato usato da Commits
+func parseLog(output string) []Commit {
+	var commits []Commit
+	for _, record := range strings.Split(output, commitSep) {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+		fields := strings.SplitN(record, fieldSep, 4)
+		if len(fields) < 4 {
+			continue
+		}
+		commits = append(commits, Commit{
+			Hash:    fields[0],
+			Subject: fields[1],
+			Body:    strings.TrimSpace(fields[2]),
+			Refs:    strings.TrimSpace(fields[3]),
+		})
+	}
+
+	return commits
+}
+
+// KeyMatcher estrae le chiavi dei ticket di un progetto dai testi dei commit
+type KeyMatcher struct {
+	re *regexp.Regexp
+}
+
+// NewKeyMatcher crea un matcher per le chiavi del progetto indicato. Il confronto ignora
+// maiuscole e minuscole, per riconoscere anche nomi di branch come feature/proj-123-login.
+func NewKeyMatcher(projectKey string) *KeyMatcher {
+	pattern := fmt.Sprintf(`(?i)(?:^|[^A-Za-z0-9])(%s-[0-9]+)(?:[^0-9]|$)`, regexp.QuoteMeta(projectKey))
+	return &KeyMatcher{re: regexp.MustCompile(pattern)}
+}
+
+// Keys restituisce le chiavi (in maiuscolo, senza duplicati) trovate nel testo
+func (m *KeyMatcher) Keys(text string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+
+	// Le corrispondenze possono condividere il separatore: si riparte dal carattere successivo alla chiave
+	for offset := 0; offset < len(text); {
+		loc := m.re.FindStringSubmatchIndex(text[offset:])
+		if loc == nil {
+			break
+		}
+		key := strings.ToUpper(text[offset+loc[2] : offset+loc[3]])
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+		offset += loc[3]
+	}
+
+	return keys
+}
+
+// CommitKeys restituisce, per ogni chiave trovata in messaggi, nomi di branch di merge e ref,
+// l'elenco dei commit che la citano.
+func (m *KeyMatcher) CommitKeys(commits []Commit) map[string][]Commit {
+	result := make(map[string][]Commit)
+	for _, commit := range commits {
+		text := strings.Join([]string{commit.Subject, commit.Body, commit.Refs}, "\n")
+		for _, key := range m.Keys(text) {
+			result[key] = append(result[key], commit)
+		}
+	}
+	return result
+}
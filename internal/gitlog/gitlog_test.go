@@ -0,0 +1,77 @@
+package gitlog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestKeyMatcherKeys(t *testing.T) {
+	matcher := NewKeyMatcher("PROJ")
+
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"chiave nel messaggio", "PROJ-123: correggi il login", []string{"PROJ-123"}},
+		{"chiave minuscola nel branch", "Merge branch 'feature/proj-45-login'", []string{"PROJ-45"}},
+		{"chiavi adiacenti", "PROJ-1,PROJ-2 PROJ-3", []string{"PROJ-1", "PROJ-2", "PROJ-3"}},
+		{"duplicati", "PROJ-7 e proj-7, ancora PROJ-7", []string{"PROJ-7"}},
+		{"altro progetto", "OTHER-5 e PROJECT-6", nil},
+		{"chiave dentro una parola", "XPROJ-8 e abcproj-9", nil},
+		{"numero più lungo", "PROJ-12 non è PROJ-1", []string{"PROJ-12", "PROJ-1"}},
+		{"chiave senza numero", "PROJ- e PROJ-x", nil},
+		{"fine del testo", "Chiude PROJ-99", []string{"PROJ-99"}},
+	}
+	for _, tt := range tests {
+		if got := matcher.Keys(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Keys(%q) = %v, attese %v", tt.name, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestParseLog(t *testing.T) {
+	record := func(fields ...string) string {
+		return strings.Join(fields, fieldSep) + commitSep
+	}
+	output := record("aaaaaaaaaaaa1", "PROJ-1: login", "Dettagli\n\nRefs: PROJ-2\n", "HEAD -> main, tag: v2.4.0") + "\n" +
+		record("bbbbbbbbbbbb2", "Merge branch 'feature/proj-3'", "", "") + "\n" +
+		"record incompleto" + commitSep + "\n"
+
+	want := []Commit{
+		{Hash: "aaaaaaaaaaaa1", Subject: "PROJ-1: login", Body: "Dettagli\n\nRefs: PROJ-2", Refs: "HEAD -> main, tag: v2.4.0"},
+		{Hash: "bbbbbbbbbbbb2", Subject: "Merge branch 'feature/proj-3'"},
+	}
+	commits := parseLog(output)
+	if !reflect.DeepEqual(commits, want) {
+		t.Fatalf("parseLog = %#v, attesi %#v", commits, want)
+	}
+	if got := commits[0].ShortHash(); got != "aaaaaaaaaa" {
+		t.Errorf("ShortHash = %q", got)
+	}
+
+	keys := NewKeyMatcher("PROJ").CommitKeys(commits)
+	if len(keys) != 3 || len(keys["PROJ-2"]) != 1 || keys["PROJ-3"][0].Hash != "bbbbbbbbbbbb2" {
+		t.Errorf("CommitKeys = %v", keys)
+	}
+}
+
+func TestCommitsRejectsOptionRefs(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "sovrascritto")
+	for _, refs := range [][2]string{
+		{"--output=" + target, "HEAD"},
+		{"v2.3.0", "--output=" + target},
+		{"-p", "HEAD"},
+	} {
+		if _, err := Commits(context.Background(), ".", refs[0], refs[1]); err == nil || !strings.Contains(err.Error(), "ref git non valido") {
+			t.Errorf("Commits(%q, %q): errore atteso, ottenuto %v", refs[0], refs[1], err)
+		}
+	}
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		t.Errorf("il file %s è stato creato", target)
+	}
+}
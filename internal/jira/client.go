@@ -1,21 +1,55 @@
 package jira
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
+
+	"jira-release-manager/internal/credentials"
 
 	"github.com/spf13/viper"
 )
 
+const (
+	// defaultTimeout è il timeout predefinito di una singola richiesta HTTP
+	defaultTimeout = 30 * time.Second
+	// defaultMaxRetries è il numero predefinito di nuovi tentativi per errori transitori
+	defaultMaxRetries = 3
+	// defaultRetryWaitMin è l'attesa di base prima del primo nuovo tentativo
+	defaultRetryWaitMin = 500 * time.Millisecond
+	// defaultRetryWaitMax è l'attesa massima tra due tentativi
+	defaultRetryWaitMax = 30 * time.Second
+	// defaultConcurrency è il numero predefinito di richieste parallele per i recuperi multipli
+	defaultConcurrency = 8
+)
+
+// defaultSubtaskTypes sono i tipi di issue considerati sub-task se JIRA_SUBTASK_TYPES non è impostata
+var defaultSubtaskTypes = []string{"Sub-task", "Sub-bug"}
+
 // Client rappresenta un client per le API Jira
 type Client struct {
 	BaseURL    string
 	Username   string
 	APIToken   string
 	HTTPClient *http.Client
+
+	Deployment    Deployment // Cloud (API v3) o Server/Data Center (API v2)
+	AuthType      AuthType   // Basic (username + API token) o Bearer (personal access token)
+	EpicLinkField string     // ID del campo personalizzato Epic Link (es. customfield_10008), per Jira Server
+	SubtaskTypes  []string   // Nomi dei tipi di issue considerati sub-task nelle query JQL
+
+	MaxRetries   int           // Numero di nuovi tentativi per 429, 502, 503, 504 ed errori di rete
+	RetryWaitMin time.Duration // Attesa di base del backoff esponenziale
+	RetryWaitMax time.Duration // Attesa massima tra due tentativi
+	Concurrency  int           // Numero massimo di richieste parallele (es. recupero sub-task)
 }
 
 // NewClient crea e restituisce un client Jira configurato.
@@ -24,63 +58,283 @@ func NewClient() (*Client, error) {
 	username := viper.GetString("JIRA_USERNAME")
 	apiToken := viper.GetString("JIRA_API_TOKEN")
 
-	if jiraURL == "" || username == "" || apiToken == "" {
-		return nil, fmt.Errorf("le credenziali Jira (JIRA_URL, JIRA_USERNAME, JIRA_API_TOKEN) non sono configurate")
+	deployment, err := ParseDeployment(viper.GetString("JIRA_DEPLOYMENT"))
+	if err != nil {
+		return nil, err
+	}
+	authType, err := ParseAuthType(viper.GetString("JIRA_AUTH_TYPE"), deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	// Senza JIRA_API_TOKEN il token viene cercato tra quelli salvati con "auth login"
+	if apiToken == "" && jiraURL != "" {
+		backend, err := credentials.ParseBackend(viper.GetString("JIRA_CREDENTIAL_STORE"))
+		if err != nil {
+			return nil, err
+		}
+		token, _, err := credentials.Lookup(credentials.Account(jiraURL, username), backend)
+		if err != nil && !errors.Is(err, credentials.ErrNotFound) {
+			return nil, fmt.Errorf("impossibile leggere il token salvato: %w", err)
+		}
+		apiToken = token
+	}
+
+	if authType == AuthBearer {
+		if jiraURL == "" || apiToken == "" {
+			return nil, fmt.Errorf("le credenziali Jira (JIRA_URL, JIRA_API_TOKEN) non sono configurate: imposta le variabili o usa \"auth login\"")
+		}
+	} else if jiraURL == "" || username == "" || apiToken == "" {
+		return nil, fmt.Errorf("le credenziali Jira (JIRA_URL, JIRA_USERNAME, JIRA_API_TOKEN) non sono configurate: imposta le variabili o usa \"auth login\"")
 	}
 
 	// Rimuovi trailing slash dall'URL se presente
 	jiraURL = strings.TrimSuffix(jiraURL, "/")
 
+	timeout := defaultTimeout
+	if viper.IsSet("JIRA_TIMEOUT") {
+		timeout = viper.GetDuration("JIRA_TIMEOUT")
+	}
+
+	maxRetries := defaultMaxRetries
+	if viper.IsSet("JIRA_MAX_RETRIES") {
+		maxRetries = viper.GetInt("JIRA_MAX_RETRIES")
+	}
+
+	concurrency := defaultConcurrency
+	if viper.IsSet("JIRA_CONCURRENCY") {
+		concurrency = viper.GetInt("JIRA_CONCURRENCY")
+	}
+
+	subtaskTypes := defaultSubtaskTypes
+	if value := viper.GetString("JIRA_SUBTASK_TYPES"); value != "" {
+		subtaskTypes = splitList(value)
+	}
+
 	return &Client{
-		BaseURL:    jiraURL,
-		Username:   username,
-		APIToken:   apiToken,
-		HTTPClient: &http.Client{},
+		BaseURL:       jiraURL,
+		Username:      username,
+		APIToken:      apiToken,
+		HTTPClient:    &http.Client{Timeout: timeout},
+		Deployment:    deployment,
+		AuthType:      authType,
+		EpicLinkField: viper.GetString("JIRA_EPIC_LINK_FIELD"),
+		SubtaskTypes:  subtaskTypes,
+		MaxRetries:    maxRetries,
+		RetryWaitMin:  defaultRetryWaitMin,
+		RetryWaitMax:  defaultRetryWaitMax,
+		Concurrency:   concurrency,
 	}, nil
 }
 
-// DoRequest esegue una richiesta HTTP con autenticazione
-func (c *Client) DoRequest(method, endpoint string, body io.Reader) ([]byte, error) {
+// WithBaseURL restituisce una copia del client che punta a un'altra applicazione Atlassian
+// (es. Confluence) con le stesse credenziali e la stessa politica di retry.
+func (c *Client) WithBaseURL(baseURL string) *Client {
+	clone := *c
+	clone.BaseURL = strings.TrimSuffix(baseURL, "/")
+	return &clone
+}
+
+// splitList divide una lista separata da virgole, scartando gli elementi vuoti
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// DoRequest esegue una richiesta HTTP con autenticazione, ripetendola in caso di errori transitori.
+// Le risposte non 2xx sono restituite come *APIError; la cancellazione di ctx interrompe anche le attese.
+func (c *Client) DoRequest(ctx context.Context, method, endpoint string, body io.Reader) ([]byte, error) {
 	url := c.BaseURL + endpoint
 
-	req, err := http.NewRequest(method, url, body)
-	if err != nil {
-		return nil, fmt.Errorf("errore nella creazione della richiesta: %w", err)
+	// Il body viene letto una sola volta per poterlo reinviare a ogni tentativo
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("errore nella lettura del body della richiesta: %w", err)
+		}
 	}
 
-	// Autenticazione Basic Auth
-	req.SetBasicAuth(c.Username, c.APIToken)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
+	for attempt := 0; ; attempt++ {
+		var reqBody io.Reader
+		if payload != nil {
+			reqBody = bytes.NewReader(payload)
+		}
 
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("errore nella richiesta HTTP: %w", err)
+		req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("errore nella creazione della richiesta: %w", err)
+		}
+
+		// Autenticazione: Basic Auth su Cloud, personal access token su Server/Data Center
+		if c.AuthType == AuthBearer {
+			req.Header.Set("Authorization", "Bearer "+c.APIToken)
+		} else {
+			req.SetBasicAuth(c.Username, c.APIToken)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("richiesta interrotta: %w", ctx.Err())
+			}
+			// Gli errori di rete sono ripetuti solo per i metodi idempotenti
+			if attempt < c.MaxRetries && isIdempotent(method) {
+				if err := sleep(ctx, c.backoff(attempt, nil)); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			return nil, fmt.Errorf("errore nella richiesta HTTP: %w", err)
+		}
+
+		responseBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("errore nella lettura della risposta: %w", err)
+		}
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return responseBody, nil
+		}
+
+		// Se Retry-After chiede di attendere oltre RetryWaitMax non si ripete: un tentativo anticipato
+		// verrebbe rifiutato di nuovo, quindi viene restituito subito l'errore
+		if attempt < c.MaxRetries && shouldRetry(method, resp.StatusCode) {
+			if wait := c.backoff(attempt, resp); wait <= c.RetryWaitMax {
+				if err := sleep(ctx, wait); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+
+		return nil, newAPIError(method, endpoint, resp.StatusCode, responseBody)
 	}
-	defer resp.Body.Close()
+}
 
-	responseBody, err := io.ReadAll(resp.Body)
+// GetJSON esegue una richiesta GET e decodifica il JSON
+func (c *Client) GetJSON(ctx context.Context, endpoint string, v interface{}) error {
+	data, err := c.DoRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
-		return nil, fmt.Errorf("errore nella lettura della risposta: %w", err)
+		return err
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("errore HTTP %d: %s", resp.StatusCode, string(responseBody))
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("errore nel parsing JSON: %w", err)
 	}
 
-	return responseBody, nil
+	return nil
 }
 
-// GetJSON esegue una richiesta GET e decodifica il JSON
-func (c *Client) GetJSON(endpoint string, v interface{}) error {
-	data, err := c.DoRequest("GET", endpoint, nil)
+// PostJSON esegue una richiesta POST con body JSON e decodifica la risposta in v (se non nil)
+func (c *Client) PostJSON(ctx context.Context, endpoint string, body interface{}, v interface{}) error {
+	return c.sendJSON(ctx, "POST", endpoint, body, v)
+}
+
+// PutJSON esegue una richiesta PUT con body JSON e decodifica la risposta in v (se non nil)
+func (c *Client) PutJSON(ctx context.Context, endpoint string, body interface{}, v interface{}) error {
+	return c.sendJSON(ctx, "PUT", endpoint, body, v)
+}
+
+// sendJSON serializza il body, esegue la richiesta e decodifica l'eventuale risposta
+func (c *Client) sendJSON(ctx context.Context, method, endpoint string, body interface{}, v interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("errore nella serializzazione JSON: %w", err)
+	}
+
+	data, err := c.DoRequest(ctx, method, endpoint, bytes.NewReader(payload))
 	if err != nil {
 		return err
 	}
 
+	if v == nil || len(data) == 0 {
+		return nil
+	}
 	if err := json.Unmarshal(data, v); err != nil {
 		return fmt.Errorf("errore nel parsing JSON: %w", err)
 	}
 
 	return nil
 }
+
+// backoff calcola l'attesa prima del prossimo tentativo: rispetta Retry-After se presente, anche
+// oltre RetryWaitMax, altrimenti usa un backoff esponenziale con jitter.
+func (c *Client) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return wait
+		}
+	}
+
+	wait := c.RetryWaitMin << attempt
+	if wait <= 0 || wait > c.RetryWaitMax {
+		wait = c.RetryWaitMax
+	}
+
+	// Jitter: attesa casuale tra metà e l'intero intervallo
+	half := wait / 2
+	if half <= 0 {
+		return wait
+	}
+	return half + time.Duration(rand.Int63n(int64(half)))
+}
+
+// sleep attende la durata indicata, interrompendosi se il contesto viene cancellato
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("richiesta interrotta: %w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+// parseRetryAfter interpreta l'header Retry-After (secondi o data HTTP)
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// shouldRetry indica se una risposta con lo status dato può essere ripetuta
+func shouldRetry(method string, statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests:
+		// La richiesta è stata rifiutata prima di essere elaborata: sempre sicuro ripetere
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	default:
+		return false
+	}
+}
+
+// isIdempotent indica se il metodo HTTP può essere ripetuto senza effetti collaterali
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "PUT", "DELETE", "OPTIONS":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,158 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newRetryServer risponde con gli status indicati, uno per richiesta, e poi con 200
+func newRetryServer(t *testing.T, statuses []int, header http.Header, requests *int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if *requests <= len(statuses) {
+			for name, values := range header {
+				w.Header()[name] = values
+			}
+			w.WriteHeader(statuses[*requests-1])
+			_, _ = w.Write([]byte(`{"errorMessages":["riprova più tardi"]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newRetryClient crea un client di test con attese di backoff trascurabili
+func newRetryClient(server *httptest.Server, maxRetries int) *Client {
+	client := newTestClient(server)
+	client.MaxRetries = maxRetries
+	client.RetryWaitMin = time.Millisecond
+	client.RetryWaitMax = 5 * time.Millisecond
+	return client
+}
+
+func TestDoRequestRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		statuses     []int
+		retryAfter   string
+		maxRetries   int
+		wantRequests int
+		wantStatus   int // 0 se la richiesta deve riuscire
+	}{
+		{"429 con Retry-After in secondi", "GET", []int{429}, "1", 3, 2, 0},
+		{"429 con Retry-After come data HTTP", "GET", []int{429}, time.Now().Add(time.Second).UTC().Format(http.TimeFormat), 3, 2, 0},
+		{"429 con Retry-After oltre il massimo", "GET", []int{429}, "120", 3, 1, 429},
+		{"429 su POST", "POST", []int{429}, "", 3, 2, 0},
+		{"503 ripetuto e poi riuscito", "GET", []int{503, 503}, "", 3, 3, 0},
+		{"503 su POST non ripetuto", "POST", []int{503}, "", 3, 1, 503},
+		{"limite di tentativi raggiunto", "GET", []int{502, 502, 502, 502}, "", 2, 3, 502},
+		{"400 non ripetuto", "PUT", []int{400}, "", 3, 1, 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.retryAfter != "" {
+				header.Set("Retry-After", tt.retryAfter)
+			}
+			requests := 0
+			server := newRetryServer(t, tt.statuses, header, &requests)
+			client := newRetryClient(server, tt.maxRetries)
+			if tt.retryAfter != "" {
+				// Abbastanza per attendere il secondo richiesto, non i 120 del caso oltre il massimo
+				client.RetryWaitMax = 2 * time.Second
+			}
+
+			data, err := client.DoRequest(context.Background(), tt.method, "/rest/api/3/myself", strings.NewReader(`{}`))
+			if requests != tt.wantRequests {
+				t.Errorf("richieste = %d, attese %d", requests, tt.wantRequests)
+			}
+			if tt.wantStatus == 0 {
+				if err != nil || string(data) != `{"ok":true}` {
+					t.Fatalf("risposta %q, errore %v", data, err)
+				}
+				return
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantStatus {
+				t.Fatalf("errore %v, atteso APIError con status %d", err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestBackoffRetryAfter(t *testing.T) {
+	client := &Client{RetryWaitMin: time.Millisecond, RetryWaitMax: time.Minute}
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", "2")
+	if wait := client.backoff(0, resp); wait != 2*time.Second {
+		t.Errorf("Retry-After in secondi: attesa %v, attesi 2s", wait)
+	}
+
+	resp.Header.Set("Retry-After", time.Now().Add(10*time.Second).UTC().Format(http.TimeFormat))
+	if wait := client.backoff(0, resp); wait <= 8*time.Second || wait > 10*time.Second {
+		t.Errorf("Retry-After come data HTTP: attesa %v, attesi circa 10s", wait)
+	}
+
+	// L'attesa indicata dal server non viene ridotta a RetryWaitMax
+	client.RetryWaitMax = time.Second
+	resp.Header.Set("Retry-After", "120")
+	if wait := client.backoff(0, resp); wait != 120*time.Second {
+		t.Errorf("Retry-After oltre il massimo: attesa %v, attesi 120s", wait)
+	}
+
+	resp.Header.Set("Retry-After", "domani")
+	if wait := client.backoff(0, resp); wait < client.RetryWaitMin/2 || wait > client.RetryWaitMin {
+		t.Errorf("Retry-After non valido: attesa %v, atteso il backoff esponenziale", wait)
+	}
+}
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			"messaggi e errori per campo",
+			`{"errorMessages":["Versione non valida"],"errors":{"name":"obbligatorio","fixVersions":"sconosciuta"}}`,
+			"errore HTTP 400 (POST /rest/api/3/version): Versione non valida; fixVersions: sconosciuta; name: obbligatorio",
+		},
+		{
+			"messaggio di Confluence",
+			`{"statusCode":400,"message":"Titolo già in uso"}`,
+			"errore HTTP 400 (POST /rest/api/3/version): Titolo già in uso",
+		},
+		{
+			"body non strutturato",
+			"  <html>Bad Request</html>\n",
+			"errore HTTP 400 (POST /rest/api/3/version): <html>Bad Request</html>",
+		},
+		{
+			"body vuoto",
+			"",
+			"errore HTTP 400 (POST /rest/api/3/version)",
+		},
+	}
+	for _, tt := range tests {
+		err := newAPIError("POST", "/rest/api/3/version", http.StatusBadRequest, []byte(tt.body))
+		if got := err.Error(); got != tt.want {
+			t.Errorf("%s: %q, atteso %q", tt.name, got, tt.want)
+		}
+	}
+
+	long := newAPIError("GET", "/x", http.StatusBadGateway, []byte(strings.Repeat("a", 300)))
+	if got := long.Error(); !strings.HasSuffix(got, "...") || len(got) > 250 {
+		t.Errorf("body lungo non troncato: %q", got)
+	}
+}
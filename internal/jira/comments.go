@@ -0,0 +1,112 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// commentPageSize è il numero di commenti richiesti per pagina
+const commentPageSize = 100
+
+// Comment rappresenta un commento di un ticket
+type Comment struct {
+	ID   string          `json:"id"`
+	Body json.RawMessage `json:"body"` // Documento ADF su Cloud, stringa su Server
+}
+
+// Text restituisce il testo semplice del commento, con i paragrafi separati da righe vuote
+func (c Comment) Text() string {
+	var text string
+	if err := json.Unmarshal(c.Body, &text); err == nil {
+		return NormalizeText(text)
+	}
+
+	var doc adfNode
+	if err := json.Unmarshal(c.Body, &doc); err != nil {
+		return ""
+	}
+	blocks := make([]string, 0, len(doc.Content))
+	for _, node := range doc.Content {
+		var sb strings.Builder
+		node.writeText(&sb)
+		blocks = append(blocks, sb.String())
+	}
+	return NormalizeText(strings.Join(blocks, "\n\n"))
+}
+
+// adfNode è un nodo di un documento ADF, limitato a quanto serve per estrarne il testo
+type adfNode struct {
+	Type    string    `json:"type"`
+	Text    string    `json:"text"`
+	Content []adfNode `json:"content"`
+}
+
+// writeText scrive il testo del nodo e dei suoi discendenti
+func (n adfNode) writeText(sb *strings.Builder) {
+	if n.Type == "hardBreak" {
+		sb.WriteString("\n")
+	}
+	sb.WriteString(n.Text)
+	for _, child := range n.Content {
+		child.writeText(sb)
+	}
+}
+
+// NormalizeText uniforma un testo come lo salva TextValue: paragrafi senza spazi iniziali
+// e finali, separati da una riga vuota, senza paragrafi vuoti.
+func NormalizeText(text string) string {
+	var paragraphs []string
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// GetComments restituisce tutti i commenti di un ticket, gestendo la paginazione
+func GetComments(ctx context.Context, client *Client, issueKey string) ([]Comment, error) {
+	var comments []Comment
+	for {
+		endpoint := client.apiPath("/issue/%s/comment?startAt=%d&maxResults=%d", issueKey, len(comments), commentPageSize)
+
+		var page struct {
+			Comments []Comment `json:"comments"`
+			Total    int       `json:"total"`
+		}
+		if err := client.GetJSON(ctx, endpoint, &page); err != nil {
+			return nil, fmt.Errorf("impossibile recuperare i commenti di %s: %w", issueKey, err)
+		}
+
+		comments = append(comments, page.Comments...)
+		if len(page.Comments) == 0 || len(comments) >= page.Total {
+			return comments, nil
+		}
+	}
+}
+
+// HasComment indica se il ticket ha già un commento con lo stesso testo, confrontato dopo NormalizeText
+func HasComment(ctx context.Context, client *Client, issueKey, text string) (bool, error) {
+	comments, err := GetComments(ctx, client, issueKey)
+	if err != nil {
+		return false, err
+	}
+	text = NormalizeText(text)
+	for _, comment := range comments {
+		if comment.Text() == text {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// AddComment aggiunge un commento a un ticket, convertendo il testo con TextValue
+func AddComment(ctx context.Context, client *Client, issueKey, text string) error {
+	body := map[string]interface{}{"body": client.TextValue(text)}
+	if err := client.PostJSON(ctx, client.apiPath("/issue/%s/comment", issueKey), body, nil); err != nil {
+		return fmt.Errorf("impossibile aggiungere il commento a %s: %w", issueKey, err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestCommentTextRoundTrip(t *testing.T) {
+	text := "Shipped in v2.4.0 on 2026-10-12\n\n  Release notes: https://example.com  \n\n\n"
+	want := "Shipped in v2.4.0 on 2026-10-12\n\nRelease notes: https://example.com"
+
+	for _, deployment := range []Deployment{DeploymentCloud, DeploymentServer} {
+		client := &Client{Deployment: deployment}
+		body, err := json.Marshal(client.TextValue(text))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := (Comment{Body: body}).Text(); got != want {
+			t.Errorf("%s: Text() = %q, want %q", deployment, got, want)
+		}
+	}
+}
+
+func TestCommentTextADF(t *testing.T) {
+	body := `{"type":"doc","version":1,"content":[
+		{"type":"paragraph","content":[{"type":"text","text":"Shipped in "},{"type":"text","text":"v2.4.0","marks":[{"type":"strong"}]}]},
+		{"type":"paragraph","content":[{"type":"text","text":"line one"},{"type":"hardBreak"},{"type":"text","text":"line two"}]}
+	]}`
+
+	want := "Shipped in v2.4.0\n\nline one\nline two"
+	if got := (Comment{Body: json.RawMessage(body)}).Text(); got != want {
+		t.Errorf("Text() = %q, want %q", got, want)
+	}
+}
+
+// newCommentServer simula l'endpoint dei commenti di PROJ-1, restituendo pagine di al massimo pageSize commenti
+func newCommentServer(t *testing.T, texts []string, pageSize int, requests *int) *httptest.Server {
+	t.Helper()
+	client := &Client{Deployment: DeploymentCloud}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/issue/PROJ-1/comment" {
+			t.Errorf("percorso inatteso: %s", r.URL.Path)
+		}
+		*requests++
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		end := min(startAt+pageSize, len(texts))
+
+		page := []map[string]interface{}{}
+		for i := startAt; i < end; i++ {
+			page = append(page, map[string]interface{}{"id": strconv.Itoa(i), "body": client.TextValue(texts[i])})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"startAt": startAt, "total": len(texts), "comments": page})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetCommentsPagination(t *testing.T) {
+	texts := make([]string, 5)
+	for i := range texts {
+		texts[i] = fmt.Sprintf("commento %d", i)
+	}
+	requests := 0
+	server := newCommentServer(t, texts, 2, &requests)
+
+	comments, err := GetComments(context.Background(), newTestClient(server), "PROJ-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 3 {
+		t.Errorf("richieste = %d, attese 3", requests)
+	}
+	if len(comments) != len(texts) {
+		t.Fatalf("commenti = %d, attesi %d", len(comments), len(texts))
+	}
+	for i, comment := range comments {
+		if comment.Text() != texts[i] {
+			t.Errorf("commento %d = %q, atteso %q", i, comment.Text(), texts[i])
+		}
+	}
+}
+
+func TestHasComment(t *testing.T) {
+	requests := 0
+	server := newCommentServer(t, []string{"Altro commento", "Shipped in v2.4.0 on 2026-10-12"}, 1, &requests)
+	client := newTestClient(server)
+
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"Shipped in v2.4.0 on 2026-10-12", true},
+		{"  Shipped in v2.4.0 on 2026-10-12\n\n", true},
+		{"Shipped in v2.4.0 on 2026-10-13", false},
+	}
+	for _, tt := range tests {
+		got, err := HasComment(context.Background(), client, "PROJ-1", tt.text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("HasComment(%q) = %v, atteso %v", tt.text, got, tt.want)
+		}
+	}
+}
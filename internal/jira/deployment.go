@@ -0,0 +1,131 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Deployment identifica il tipo di installazione Jira
+type Deployment string
+
+const (
+	// DeploymentCloud è Jira Cloud: API v3, descrizioni in ADF, Basic auth con API token
+	DeploymentCloud Deployment = "cloud"
+	// DeploymentServer è Jira Server / Data Center: API v2, descrizioni in testo semplice, PAT Bearer
+	DeploymentServer Deployment = "server"
+)
+
+// AuthType identifica il tipo di autenticazione HTTP
+type AuthType string
+
+const (
+	// AuthBasic usa JIRA_USERNAME e JIRA_API_TOKEN (predefinito su Cloud)
+	AuthBasic AuthType = "basic"
+	// AuthBearer usa JIRA_API_TOKEN come personal access token (predefinito su Server)
+	AuthBearer AuthType = "bearer"
+)
+
+// ParseDeployment valida il valore di JIRA_DEPLOYMENT
+func ParseDeployment(value string) (Deployment, error) {
+	switch d := Deployment(strings.ToLower(strings.TrimSpace(value))); d {
+	case "":
+		return DeploymentCloud, nil
+	case DeploymentCloud, DeploymentServer:
+		return d, nil
+	case "datacenter", "data-center", "dc":
+		return DeploymentServer, nil
+	default:
+		return "", fmt.Errorf("tipo di installazione Jira non valido: %q (valori ammessi: cloud, server)", value)
+	}
+}
+
+// ParseAuthType valida il valore di JIRA_AUTH_TYPE; se vuoto usa il default del deployment
+func ParseAuthType(value string, deployment Deployment) (AuthType, error) {
+	switch a := AuthType(strings.ToLower(strings.TrimSpace(value))); a {
+	case "":
+		if deployment == DeploymentServer {
+			return AuthBearer, nil
+		}
+		return AuthBasic, nil
+	case AuthBasic, AuthBearer:
+		return a, nil
+	case "pat":
+		return AuthBearer, nil
+	default:
+		return "", fmt.Errorf("tipo di autenticazione non valido: %q (valori ammessi: basic, bearer)", value)
+	}
+}
+
+// apiPath costruisce il percorso REST per la versione di API del deployment
+func (c *Client) apiPath(format string, args ...interface{}) string {
+	version := "3"
+	if c.Deployment == DeploymentServer {
+		version = "2"
+	}
+	return fmt.Sprintf("/rest/api/%s", version) + fmt.Sprintf(format, args...)
+}
+
+// searchPath restituisce l'endpoint di ricerca JQL: /search/jql su Cloud, /search su Server
+func (c *Client) searchPath() string {
+	if c.Deployment == DeploymentServer {
+		return c.apiPath("/search")
+	}
+	return c.apiPath("/search/jql")
+}
+
+// withEpicLink aggiunge il campo Epic Link configurato all'elenco dei campi richiesti
+func (c *Client) withEpicLink(fields string) string {
+	if c.EpicLinkField == "" {
+		return fields
+	}
+	return fields + "," + c.EpicLinkField
+}
+
+// applyEpicLink popola Fields.Epic a partire dal campo personalizzato Epic Link, usato da Jira Server
+// al posto del campo "epic". raw è il JSON di un ticket ({"fields": {...}}).
+func (c *Client) applyEpicLink(raw json.RawMessage, issue *Issue) {
+	if c.EpicLinkField == "" || (issue.Fields.Epic != nil && issue.Fields.Epic.Key != "") {
+		return
+	}
+
+	var payload struct {
+		Fields map[string]json.RawMessage `json:"fields"`
+	}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return
+	}
+
+	var epicKey string
+	if err := json.Unmarshal(payload.Fields[c.EpicLinkField], &epicKey); err == nil && epicKey != "" {
+		issue.Fields.Epic = &EpicLink{Key: epicKey}
+	}
+}
+
+// TextValue converte un testo semplice nel formato atteso dai campi rich text (descrizioni,
+// commenti): un documento ADF su Cloud, una stringa su Server. I paragrafi sono separati da righe vuote.
+func (c *Client) TextValue(text string) interface{} {
+	if c.Deployment == DeploymentServer {
+		return text
+	}
+
+	var paragraphs []interface{}
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		paragraphs = append(paragraphs, map[string]interface{}{
+			"type": "paragraph",
+			"content": []interface{}{
+				map[string]interface{}{"type": "text", "text": paragraph},
+			},
+		})
+	}
+
+	return map[string]interface{}{
+		"type":    "doc",
+		"version": 1,
+		"content": paragraphs,
+	}
+}
@@ -0,0 +1,87 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDeploymentPaths(t *testing.T) {
+	tests := []struct {
+		deployment Deployment
+		wantIssue  string
+		wantSearch string
+	}{
+		{DeploymentCloud, "/rest/api/3/issue/PROJ-1", "/rest/api/3/search/jql"},
+		{DeploymentServer, "/rest/api/2/issue/PROJ-1", "/rest/api/2/search"},
+	}
+	for _, tt := range tests {
+		client := &Client{Deployment: tt.deployment}
+		if got := client.apiPath("/issue/%s", "PROJ-1"); got != tt.wantIssue {
+			t.Errorf("%s: apiPath = %q, atteso %q", tt.deployment, got, tt.wantIssue)
+		}
+		if got := client.searchPath(); got != tt.wantSearch {
+			t.Errorf("%s: searchPath = %q, atteso %q", tt.deployment, got, tt.wantSearch)
+		}
+	}
+}
+
+func TestDoRequestAuthorization(t *testing.T) {
+	tests := []struct {
+		authType AuthType
+		want     string
+	}{
+		{AuthBearer, "Bearer token"},
+		{AuthBasic, "Basic dXNlcjp0b2tlbg=="}, // user:token
+	}
+	for _, tt := range tests {
+		var got string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r.Header.Get("Authorization")
+			_, _ = w.Write([]byte(`{}`))
+		}))
+
+		client := newTestClient(server)
+		client.AuthType = tt.authType
+		if _, err := client.DoRequest(context.Background(), "GET", "/rest/api/2/myself", nil); err != nil {
+			t.Fatal(err)
+		}
+		server.Close()
+
+		if got != tt.want {
+			t.Errorf("%s: Authorization = %q, atteso %q", tt.authType, got, tt.want)
+		}
+	}
+}
+
+func TestApplyEpicLink(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string
+		raw   string
+		want  string
+	}{
+		{"campo Epic Link di Server", "customfield_10008", `{"key":"PROJ-2","fields":{"customfield_10008":"PROJ-1"}}`, "PROJ-1"},
+		{"campo epic già valorizzato", "customfield_10008", `{"key":"PROJ-2","fields":{"epic":{"key":"PROJ-9"},"customfield_10008":"PROJ-1"}}`, "PROJ-9"},
+		{"campo Epic Link vuoto", "customfield_10008", `{"key":"PROJ-2","fields":{"customfield_10008":null}}`, ""},
+		{"campo non configurato", "", `{"key":"PROJ-2","fields":{"customfield_10008":"PROJ-1"}}`, ""},
+	}
+	for _, tt := range tests {
+		var issue Issue
+		if err := json.Unmarshal([]byte(tt.raw), &issue); err != nil {
+			t.Fatal(err)
+		}
+		client := &Client{Deployment: DeploymentServer, EpicLinkField: tt.field}
+		client.applyEpicLink(json.RawMessage(tt.raw), &issue)
+
+		got := ""
+		if issue.Fields.Epic != nil {
+			got = issue.Fields.Epic.Key
+		}
+		if got != tt.want {
+			t.Errorf("%s: epic = %q, atteso %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package jira
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// APIError rappresenta una risposta non 2xx delle API Jira
+type APIError struct {
+	StatusCode    int
+	Method        string
+	Endpoint      string
+	ErrorMessages []string          // Messaggi generici restituiti da Jira (o Confluence)
+	Errors        map[string]string // Errori per campo restituiti da Jira
+	Body          string            // Body grezzo, se non è un errore Jira strutturato
+}
+
+// Error restituisce una descrizione leggibile dell'errore
+func (e *APIError) Error() string {
+	var details []string
+	details = append(details, e.ErrorMessages...)
+
+	fields := make([]string, 0, len(e.Errors))
+	for field := range e.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		details = append(details, fmt.Sprintf("%s: %s", field, e.Errors[field]))
+	}
+
+	if len(details) == 0 && e.Body != "" {
+		body := e.Body
+		if len(body) > 200 {
+			body = body[:197] + "..."
+		}
+		details = append(details, body)
+	}
+
+	msg := fmt.Sprintf("errore HTTP %d (%s %s)", e.StatusCode, e.Method, e.Endpoint)
+	if len(details) > 0 {
+		msg += ": " + strings.Join(details, "; ")
+	}
+	return msg
+}
+
+// newAPIError costruisce un APIError interpretando il body di errore di Jira
+func newAPIError(method, endpoint string, statusCode int, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Method:     method,
+		Endpoint:   endpoint,
+	}
+
+	// Jira restituisce errorMessages/errors; le API di Confluence un unico campo message
+	var payload struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+		Message       string            `json:"message"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil && (len(payload.ErrorMessages) > 0 || len(payload.Errors) > 0) {
+		apiErr.ErrorMessages = payload.ErrorMessages
+		apiErr.Errors = payload.Errors
+	} else if err == nil && payload.Message != "" {
+		apiErr.ErrorMessages = []string{payload.Message}
+	} else {
+		apiErr.Body = strings.TrimSpace(string(body))
+	}
+
+	return apiErr
+}
+
+// hasStatus verifica se err è un APIError con lo status indicato
+func hasStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+// IsUnauthorized indica se l'errore è dovuto a credenziali non valide
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsNotFound indica se la risorsa richiesta non esiste (o non è visibile)
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsRateLimited indica se Jira ha rifiutato la richiesta per troppe chiamate
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
@@ -0,0 +1,108 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StatusFilter indica quali ticket includere in base alla categoria di stato
+type StatusFilter string
+
+const (
+	// StatusOpen include solo i ticket non completati (predefinito)
+	StatusOpen StatusFilter = "open"
+	// StatusDone include solo i ticket completati
+	StatusDone StatusFilter = "done"
+	// StatusAll include tutti i ticket
+	StatusAll StatusFilter = "all"
+)
+
+// ParseStatusFilter valida il valore del flag --status
+func ParseStatusFilter(value string) (StatusFilter, error) {
+	switch f := StatusFilter(strings.ToLower(strings.TrimSpace(value))); f {
+	case "":
+		return StatusOpen, nil
+	case StatusOpen, StatusDone, StatusAll:
+		return f, nil
+	default:
+		return "", fmt.Errorf("filtro di stato non valido: %q (valori ammessi: all, open, done)", value)
+	}
+}
+
+// IssueFilter contiene i filtri applicati al recupero dei ticket di una versione
+type IssueFilter struct {
+	Status StatusFilter // Categoria di stato dei ticket da includere
+	JQL    string       // Clausole JQL aggiuntive, messe in AND con la query (es. component = API)
+}
+
+// clauses restituisce le condizioni JQL del filtro, da aggiungere in AND a una query
+func (f IssueFilter) clauses() string {
+	var sb strings.Builder
+	switch f.Status {
+	case StatusDone:
+		sb.WriteString(" AND statusCategory = Done")
+	case StatusAll:
+	default:
+		sb.WriteString(" AND statusCategory != Done")
+	}
+	if jql := strings.TrimSpace(f.JQL); jql != "" {
+		sb.WriteString(fmt.Sprintf(" AND (%s)", jql))
+	}
+	return sb.String()
+}
+
+// Matches verifica se un ticket recuperato singolarmente rispetta il filtro di stato
+func (f IssueFilter) Matches(issue *Issue) bool {
+	switch f.Status {
+	case StatusDone:
+		return issue.IsCompleted()
+	case StatusAll:
+		return true
+	default:
+		return !issue.IsCompleted()
+	}
+}
+
+// Description restituisce una descrizione leggibile del filtro di stato
+func (f IssueFilter) Description() string {
+	switch f.Status {
+	case StatusDone:
+		return "Solo ticket completati"
+	case StatusAll:
+		return "Tutti i ticket, inclusi quelli completati"
+	default:
+		return "Esclusi i ticket completati"
+	}
+}
+
+// SplitOrderBy separa le condizioni di una query JQL dalla clausola ORDER BY finale, che non può
+// comparire dentro le parentesi quando la query viene messa in AND con altre condizioni.
+// Le occorrenze tra virgolette vengono ignorate; orderBy è vuoto se la clausola manca.
+func SplitOrderBy(jql string) (where, orderBy string) {
+	upper := strings.ToUpper(jql)
+	var quote byte
+	for i := 0; i < len(jql); i++ {
+		c := jql[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case strings.HasPrefix(upper[i:], "ORDER") && (i == 0 || isJQLSpace(jql[i-1])):
+			rest := strings.TrimLeft(upper[i+len("ORDER"):], " \t\r\n")
+			if len(rest) < len(upper[i+len("ORDER"):]) && strings.HasPrefix(rest, "BY") {
+				return strings.TrimSpace(jql[:i]), strings.TrimSpace(jql[i:])
+			}
+		}
+	}
+	return strings.TrimSpace(jql), ""
+}
+
+// isJQLSpace indica se un carattere separa le parole di una query JQL
+func isJQLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == ')'
+}
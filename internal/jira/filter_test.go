@@ -0,0 +1,23 @@
+package jira
+
+import "testing"
+
+func TestSplitOrderBy(t *testing.T) {
+	tests := []struct {
+		jql, where, orderBy string
+	}{
+		{"fixVersion = 2.3.0", "fixVersion = 2.3.0", ""},
+		{"fixVersion = 2.3.0 ORDER BY priority DESC", "fixVersion = 2.3.0", "ORDER BY priority DESC"},
+		{"labels = api order  by key", "labels = api", "order  by key"},
+		{"ORDER BY created", "", "ORDER BY created"},
+		{`summary ~ "order by" ORDER BY key`, `summary ~ "order by"`, "ORDER BY key"},
+		{"(status = Open)ORDER BY key", "(status = Open)", "ORDER BY key"},
+		{"reorder = 1 AND bordered = 2", "reorder = 1 AND bordered = 2", ""},
+	}
+	for _, tt := range tests {
+		where, orderBy := SplitOrderBy(tt.jql)
+		if where != tt.where || orderBy != tt.orderBy {
+			t.Errorf("SplitOrderBy(%q) = (%q, %q), atteso (%q, %q)", tt.jql, where, orderBy, tt.where, tt.orderBy)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// FixVersionChange descrive le fixVersion da aggiungere e rimuovere su un ticket
+type FixVersionChange struct {
+	Add    []string // Nomi delle versioni da aggiungere
+	Remove []string // Nomi delle versioni da rimuovere
+}
+
+// IsEmpty indica se la modifica non aggiunge né rimuove versioni
+func (c FixVersionChange) IsEmpty() bool {
+	return len(c.Add) == 0 && len(c.Remove) == 0
+}
+
+// FixVersionUpdate è la modifica delle fixVersion da applicare a un ticket
+type FixVersionUpdate struct {
+	Issue Issue
+	FixVersionChange
+}
+
+// UpdateFixVersions aggiunge e rimuove fixVersion su un ticket con l'operazione update,
+// lasciando invariate le altre versioni già assegnate.
+func UpdateFixVersions(ctx context.Context, client *Client, issueKey string, change FixVersionChange) error {
+	type versionName struct {
+		Name string `json:"name"`
+	}
+	operations := make([]map[string]versionName, 0, len(change.Add)+len(change.Remove))
+	for _, name := range change.Add {
+		operations = append(operations, map[string]versionName{"add": {Name: name}})
+	}
+	for _, name := range change.Remove {
+		operations = append(operations, map[string]versionName{"remove": {Name: name}})
+	}
+
+	body := map[string]interface{}{
+		"update": map[string]interface{}{"fixVersions": operations},
+	}
+	if err := client.PutJSON(ctx, client.apiPath("/issue/%s", issueKey), body, nil); err != nil {
+		return fmt.Errorf("impossibile aggiornare le fixVersion di %s: %w", issueKey, err)
+	}
+	return nil
+}
+
+// Transition rappresenta una transizione del workflow disponibile per un ticket
+type Transition struct {
+	ID     string                     `json:"id"`
+	Name   string                     `json:"name"`
+	To     Status                     `json:"to"`
+	Fields map[string]json.RawMessage `json:"fields,omitempty"` // Campi della schermata di transizione
+}
+
+// HasField indica se il campo indicato (es. resolution) è presente nella schermata della transizione
+func (t Transition) HasField(field string) bool {
+	_, ok := t.Fields[field]
+	return ok
+}
+
+// GetTransitions restituisce le transizioni disponibili per un ticket nel suo stato attuale,
+// con i campi delle rispettive schermate.
+func GetTransitions(ctx context.Context, client *Client, issueKey string) ([]Transition, error) {
+	endpoint := client.apiPath("/issue/%s/transitions?expand=transitions.fields", issueKey)
+
+	var response struct {
+		Transitions []Transition `json:"transitions"`
+	}
+	if err := client.GetJSON(ctx, endpoint, &response); err != nil {
+		return nil, fmt.Errorf("impossibile recuperare le transizioni di %s: %w", issueKey, err)
+	}
+
+	return response.Transitions, nil
+}
+
+// TransitionIssue esegue una transizione su un ticket, impostando la risoluzione e aggiungendo
+// un commento se non vuoti.
+func TransitionIssue(ctx context.Context, client *Client, issueKey, transitionID, resolution, comment string) error {
+	body := map[string]interface{}{
+		"transition": map[string]string{"id": transitionID},
+	}
+	if resolution != "" {
+		body["fields"] = map[string]interface{}{
+			"resolution": map[string]string{"name": resolution},
+		}
+	}
+	if comment != "" {
+		body["update"] = map[string]interface{}{
+			"comment": []interface{}{
+				map[string]interface{}{"add": map[string]interface{}{"body": client.TextValue(comment)}},
+			},
+		}
+	}
+
+	if err := client.PostJSON(ctx, client.apiPath("/issue/%s/transitions", issueKey), body, nil); err != nil {
+		return fmt.Errorf("impossibile eseguire la transizione su %s: %w", issueKey, err)
+	}
+	return nil
+}
+
+// GetPriorities restituisce le priorità definite in Jira, dalla più alla meno urgente
+func GetPriorities(ctx context.Context, client *Client) ([]Priority, error) {
+	var priorities []Priority
+	if err := client.GetJSON(ctx, client.apiPath("/priority"), &priorities); err != nil {
+		return nil, fmt.Errorf("impossibile recuperare le priorità: %w", err)
+	}
+	return priorities, nil
+}
@@ -0,0 +1,140 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// recordJSONServer risponde con response e salva percorso e body JSON dell'ultima richiesta
+func recordJSONServer(t *testing.T, response string, uri *string, body *map[string]interface{}) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*uri = r.Method + " " + r.URL.RequestURI()
+		if r.Method != http.MethodGet {
+			*body = nil
+			if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+				t.Errorf("payload non valido: %v", err)
+			}
+		}
+		if response == "" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetTransitions(t *testing.T) {
+	var uri string
+	server := recordJSONServer(t, `{"transitions":[
+		{"id":"31","name":"Done","to":{"name":"Done","statusCategory":{"key":"done"}},"fields":{"resolution":{"required":true}}},
+		{"id":"21","name":"In Progress","to":{"name":"In Progress","statusCategory":{"key":"indeterminate"}},"fields":{}}
+	]}`, &uri, nil)
+
+	transitions, err := GetTransitions(context.Background(), newTestClient(server), "PROJ-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "GET /rest/api/3/issue/PROJ-1/transitions?expand=transitions.fields"; uri != want {
+		t.Errorf("richiesta %q, attesa %q", uri, want)
+	}
+	if len(transitions) != 2 || transitions[0].To.Name != "Done" {
+		t.Fatalf("transizioni %+v", transitions)
+	}
+	if !transitions[0].HasField("resolution") || transitions[1].HasField("resolution") {
+		t.Errorf("campo resolution: %v, %v", transitions[0].HasField("resolution"), transitions[1].HasField("resolution"))
+	}
+}
+
+func TestTransitionIssue(t *testing.T) {
+	adfComment := map[string]interface{}{
+		"type":    "doc",
+		"version": float64(1),
+		"content": []interface{}{map[string]interface{}{
+			"type":    "paragraph",
+			"content": []interface{}{map[string]interface{}{"type": "text", "text": "Rilasciato in 2.4.0"}},
+		}},
+	}
+
+	tests := []struct {
+		name       string
+		deployment Deployment
+		resolution string
+		comment    string
+		wantURI    string
+		want       map[string]interface{}
+	}{
+		{
+			"solo transizione", DeploymentCloud, "", "",
+			"POST /rest/api/3/issue/PROJ-1/transitions",
+			map[string]interface{}{"transition": map[string]interface{}{"id": "31"}},
+		},
+		{
+			"risoluzione e commento ADF su Cloud", DeploymentCloud, "Done", "Rilasciato in 2.4.0",
+			"POST /rest/api/3/issue/PROJ-1/transitions",
+			map[string]interface{}{
+				"transition": map[string]interface{}{"id": "31"},
+				"fields":     map[string]interface{}{"resolution": map[string]interface{}{"name": "Done"}},
+				"update": map[string]interface{}{"comment": []interface{}{
+					map[string]interface{}{"add": map[string]interface{}{"body": adfComment}},
+				}},
+			},
+		},
+		{
+			"commento testuale su Server", DeploymentServer, "", "Rilasciato in 2.4.0",
+			"POST /rest/api/2/issue/PROJ-1/transitions",
+			map[string]interface{}{
+				"transition": map[string]interface{}{"id": "31"},
+				"update": map[string]interface{}{"comment": []interface{}{
+					map[string]interface{}{"add": map[string]interface{}{"body": "Rilasciato in 2.4.0"}},
+				}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		var uri string
+		var body map[string]interface{}
+		client := newTestClient(recordJSONServer(t, "", &uri, &body))
+		client.Deployment = tt.deployment
+
+		if err := TransitionIssue(context.Background(), client, "PROJ-1", "31", tt.resolution, tt.comment); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if uri != tt.wantURI {
+			t.Errorf("%s: richiesta %q, attesa %q", tt.name, uri, tt.wantURI)
+		}
+		if !reflect.DeepEqual(body, tt.want) {
+			t.Errorf("%s: body %v, atteso %v", tt.name, body, tt.want)
+		}
+	}
+}
+
+func TestUpdateFixVersions(t *testing.T) {
+	var uri string
+	var body map[string]interface{}
+	client := newTestClient(recordJSONServer(t, "", &uri, &body))
+
+	change := FixVersionChange{Add: []string{"2.4.0"}, Remove: []string{"2.3.0", "2.3.1"}}
+	if err := UpdateFixVersions(context.Background(), client, "PROJ-1", change); err != nil {
+		t.Fatal(err)
+	}
+	if want := "PUT /rest/api/3/issue/PROJ-1"; uri != want {
+		t.Errorf("richiesta %q, attesa %q", uri, want)
+	}
+
+	// Le operazioni update lasciano invariate le altre fixVersion del ticket
+	want := map[string]interface{}{"update": map[string]interface{}{"fixVersions": []interface{}{
+		map[string]interface{}{"add": map[string]interface{}{"name": "2.4.0"}},
+		map[string]interface{}{"remove": map[string]interface{}{"name": "2.3.0"}},
+		map[string]interface{}{"remove": map[string]interface{}{"name": "2.3.1"}},
+	}}}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("body %v, atteso %v", body, want)
+	}
+}
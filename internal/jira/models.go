@@ -2,6 +2,7 @@ package jira
 
 // Project rappresenta un progetto Jira
 type Project struct {
+	ID       string    `json:"id"`
 	Key      string    `json:"key"`
 	Name     string    `json:"name"`
 	Versions []Version `json:"versions"`
@@ -10,6 +11,7 @@ type Project struct {
 // Version rappresenta una versione di rilascio
 type Version struct {
 	ID          string `json:"id"`
+	Self        string `json:"self"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Archived    bool   `json:"archived"`
@@ -18,12 +20,14 @@ type Version struct {
 	StartDate   string `json:"startDate"`
 }
 
-// SearchResults rappresenta i risultati di una ricerca JQL
+// SearchResults rappresenta una pagina di risultati di una ricerca JQL
 type SearchResults struct {
-	Issues     []Issue `json:"issues"`
-	Total      int     `json:"total"`
-	MaxResults int     `json:"maxResults"`
-	StartAt    int     `json:"startAt"`
+	Issues        []Issue `json:"issues"`
+	Total         int     `json:"total"`
+	MaxResults    int     `json:"maxResults"`
+	StartAt       int     `json:"startAt"`
+	NextPageToken string  `json:"nextPageToken,omitempty"`
+	IsLast        bool    `json:"isLast,omitempty"`
 }
 
 // Issue rappresenta un ticket Jira
@@ -47,6 +51,8 @@ type IssueFields struct {
 	Epic        *EpicLink   `json:"epic,omitempty"` // Link all'epic
 	Subtasks    []IssueRef  `json:"subtasks"`
 	Labels      []string    `json:"labels,omitempty"` // <<< CAMPO AGGIUNTO
+	Components  []Component `json:"components,omitempty"`
+	FixVersions []Version   `json:"fixVersions,omitempty"`
 }
 
 // Status rappresenta lo stato di un ticket
@@ -75,9 +81,16 @@ type IssueType struct {
 	Subtask bool   `json:"subtask"`
 }
 
+// Component rappresenta un componente del progetto
+type Component struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
 // User rappresenta un utente Jira
 type User struct {
 	AccountID    string `json:"accountId"`
+	Name         string `json:"name,omitempty"` // Username su Jira Server / Data Center
 	DisplayName  string `json:"displayName"`
 	EmailAddress string `json:"emailAddress"`
 }
@@ -0,0 +1,89 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// searchPageSize è il numero di ticket richiesti per ogni pagina di ricerca
+const searchPageSize = 100
+
+// issueFields sono i campi richiesti per i ticket di una release
+const issueFields = "summary,status,assignee,priority,issuetype,parent,subtasks,epic,labels,components,fixVersions"
+
+// SearchIssues esegue una ricerca JQL e restituisce tutti i ticket, seguendo la paginazione.
+// Supporta sia il contratto legacy (startAt/total) sia quello di /search/jql (nextPageToken/isLast).
+func SearchIssues(ctx context.Context, client *Client, jql string, fields string) ([]Issue, error) {
+	var allIssues []Issue
+	startAt := 0
+	nextPageToken := ""
+
+	for {
+		params := url.Values{}
+		params.Add("jql", jql)
+		params.Add("maxResults", strconv.Itoa(searchPageSize))
+		params.Add("fields", client.withEpicLink(fields))
+		if nextPageToken != "" {
+			params.Add("nextPageToken", nextPageToken)
+		} else {
+			params.Add("startAt", strconv.Itoa(startAt))
+		}
+
+		endpoint := fmt.Sprintf("%s?%s", client.searchPath(), params.Encode())
+
+		page, err := client.searchPage(ctx, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		allIssues = append(allIssues, page.Issues...)
+
+		// Contratto /search/jql: si prosegue finché c'è un token e non è l'ultima pagina
+		if page.NextPageToken != "" {
+			if page.IsLast || page.NextPageToken == nextPageToken {
+				break
+			}
+			nextPageToken = page.NextPageToken
+			continue
+		}
+
+		// Contratto legacy: si prosegue finché non si raggiunge il totale
+		if page.IsLast || len(page.Issues) == 0 {
+			break
+		}
+		startAt += len(page.Issues)
+		if startAt >= page.Total {
+			break
+		}
+	}
+
+	return allIssues, nil
+}
+
+// searchPage recupera e decodifica una pagina di risultati, normalizzando l'Epic Link per Jira Server
+func (c *Client) searchPage(ctx context.Context, endpoint string) (*SearchResults, error) {
+	var raw json.RawMessage
+	if err := c.GetJSON(ctx, endpoint, &raw); err != nil {
+		return nil, err
+	}
+
+	var page SearchResults
+	if err := json.Unmarshal(raw, &page); err != nil {
+		return nil, fmt.Errorf("errore nel parsing JSON: %w", err)
+	}
+
+	if c.EpicLinkField != "" {
+		var rawPage struct {
+			Issues []json.RawMessage `json:"issues"`
+		}
+		if err := json.Unmarshal(raw, &rawPage); err == nil && len(rawPage.Issues) == len(page.Issues) {
+			for i := range page.Issues {
+				c.applyEpicLink(rawPage.Issues[i], &page.Issues[i])
+			}
+		}
+	}
+
+	return &page, nil
+}
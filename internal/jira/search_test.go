@@ -0,0 +1,105 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// newTestClient crea un client che punta al server di test
+func newTestClient(server *httptest.Server) *Client {
+	return &Client{
+		BaseURL:    server.URL,
+		Username:   "user",
+		APIToken:   "token",
+		HTTPClient: server.Client(),
+	}
+}
+
+// makeIssues genera n ticket fittizi a partire dall'indice start
+func makeIssues(start, n int) []Issue {
+	issues := make([]Issue, n)
+	for i := range issues {
+		issues[i] = Issue{Key: fmt.Sprintf("PROJ-%d", start+i+1)}
+	}
+	return issues
+}
+
+func TestSearchIssuesLegacyPagination(t *testing.T) {
+	const total = 250
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		n := min(searchPageSize, total-startAt)
+		_ = json.NewEncoder(w).Encode(SearchResults{
+			Issues:     makeIssues(startAt, n),
+			Total:      total,
+			MaxResults: searchPageSize,
+			StartAt:    startAt,
+		})
+	}))
+	defer server.Close()
+
+	issues, err := SearchIssues(context.Background(), newTestClient(server), `project = "PROJ"`, issueFields)
+	if err != nil {
+		t.Fatalf("errore inatteso: %v", err)
+	}
+	if len(issues) != total {
+		t.Fatalf("attesi %d ticket, ottenuti %d", total, len(issues))
+	}
+	if requests != 3 {
+		t.Fatalf("attese 3 richieste, effettuate %d", requests)
+	}
+	if issues[total-1].Key != "PROJ-250" {
+		t.Fatalf("ultimo ticket inatteso: %s", issues[total-1].Key)
+	}
+}
+
+func TestSearchIssuesNextPageToken(t *testing.T) {
+	pages := map[string]SearchResults{
+		"":       {Issues: makeIssues(0, 100), NextPageToken: "page-2"},
+		"page-2": {Issues: makeIssues(100, 100), NextPageToken: "page-3"},
+		"page-3": {Issues: makeIssues(200, 42), IsLast: true},
+	}
+	var tokens []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("nextPageToken")
+		tokens = append(tokens, token)
+		page, ok := pages[token]
+		if !ok {
+			http.Error(w, "token sconosciuto", http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	issues, err := SearchIssues(context.Background(), newTestClient(server), `project = "PROJ"`, issueFields)
+	if err != nil {
+		t.Fatalf("errore inatteso: %v", err)
+	}
+	if len(issues) != 242 {
+		t.Fatalf("attesi 242 ticket, ottenuti %d", len(issues))
+	}
+	if len(tokens) != 3 || tokens[1] != "page-2" || tokens[2] != "page-3" {
+		t.Fatalf("sequenza di token inattesa: %v", tokens)
+	}
+}
+
+func TestSearchIssuesError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if _, err := SearchIssues(context.Background(), newTestClient(server), `project = "PROJ"`, issueFields); err == nil {
+		t.Fatal("atteso un errore per risposta 500")
+	}
+}
@@ -1,18 +1,34 @@
 package jira
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"net/url"
+	"os"
 	"sort"
 	"strings"
+	"sync"
 )
 
+// GetCurrentUser restituisce l'utente autenticato con le credenziali del client.
+// È il modo più economico per verificare che URL e token siano validi.
+func GetCurrentUser(ctx context.Context, client *Client) (*User, error) {
+	var user User
+	if err := client.GetJSON(ctx, client.apiPath("/myself"), &user); err != nil {
+		return nil, fmt.Errorf("impossibile verificare le credenziali: %w", err)
+	}
+	return &user, nil
+}
+
 // GetAllProjectVersions recupera tutte le versioni per un progetto, ordinate.
-func GetAllProjectVersions(client *Client, projectKey string) ([]Version, error) {
-	endpoint := fmt.Sprintf("/rest/api/3/project/%s?expand=versions", projectKey)
+func GetAllProjectVersions(ctx context.Context, client *Client, projectKey string) ([]Version, error) {
+	endpoint := client.apiPath("/project/%s?expand=versions", projectKey)
 
 	var project Project
-	if err := client.GetJSON(endpoint, &project); err != nil {
+	if err := client.GetJSON(ctx, endpoint, &project); err != nil {
+		if IsNotFound(err) {
+			return nil, fmt.Errorf("il progetto %s non esiste o non è visibile con le credenziali configurate: %w", projectKey, err)
+		}
 		return nil, fmt.Errorf("impossibile recuperare il progetto %s: %w", projectKey, err)
 	}
 
@@ -41,8 +57,8 @@ func GetAllProjectVersions(client *Client, projectKey string) ([]Version, error)
 }
 
 // FindNextReleaseVersion trova la prima versione non rilasciata per un dato progetto.
-func FindNextReleaseVersion(client *Client, projectKey string) (*Version, error) {
-	versions, err := GetAllProjectVersions(client, projectKey)
+func FindNextReleaseVersion(ctx context.Context, client *Client, projectKey string) (*Version, error) {
+	versions, err := GetAllProjectVersions(ctx, client, projectKey)
 	if err != nil {
 		return nil, err
 	}
@@ -63,34 +79,123 @@ func FindNextReleaseVersion(client *Client, projectKey string) (*Version, error)
 	return &unreleasedVersions[0], nil
 }
 
-// GetIssuesForVersion recupera tutti i ticket per una versione specifica usando /rest/api/3/search/jql
-func GetIssuesForVersion(client *Client, projectKey string, versionName string) ([]Issue, error) {
-	fmt.Print("⏳ Recupero ticket in rilascio...")
+// FindLatestReleasedVersion trova l'ultima versione rilasciata (non archiviata) per un dato progetto.
+func FindLatestReleasedVersion(ctx context.Context, client *Client, projectKey string) (*Version, error) {
+	versions, err := GetAllProjectVersions(ctx, client, projectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if latest := latestReleased(versions); latest != nil {
+		return latest, nil
+	}
+	return nil, fmt.Errorf("nessuna versione rilasciata trovata per il progetto %s", projectKey)
+}
+
+// latestReleased restituisce la versione rilasciata (non archiviata) con la data di rilascio più recente.
+// Le versioni senza data, ordinate in coda da GetAllProjectVersions, sono considerate solo se nessuna
+// versione rilasciata ha una data.
+func latestReleased(versions []Version) *Version {
+	var released []Version
+	for _, v := range versions {
+		if v.Released && !v.Archived {
+			released = append(released, v)
+		}
+	}
+	if latest := LatestDated(released); latest != nil {
+		return latest
+	}
+	if len(released) > 0 {
+		return &released[len(released)-1]
+	}
+	return nil
+}
 
-	// JQL per trovare tutte le issue nella versione specificata, escludendo quelle completate
-	jql := fmt.Sprintf(`project = "%s" AND fixVersion = "%s" AND statusCategory != Done AND issuetype not in (Sub-task, Sub-bug)`, projectKey, versionName)
+// LatestDated restituisce la versione con la data di rilascio più recente, ignorando quelle
+// senza data; a parità di data vale l'ultima nell'ordine dato. Restituisce nil se nessuna ha una data.
+func LatestDated(versions []Version) *Version {
+	var latest *Version
+	for i := range versions {
+		v := &versions[i]
+		if v.ReleaseDate != "" && (latest == nil || v.ReleaseDate >= latest.ReleaseDate) {
+			latest = v
+		}
+	}
+	return latest
+}
 
-	params := url.Values{}
-	params.Add("jql", jql)
-	params.Add("startAt", "0")
-	params.Add("maxResults", "100")
-	params.Add("fields", "summary,status,assignee,priority,issuetype,parent,subtasks,epic,labels")
+// VersionsBetween restituisce le versioni comprese tra from e to (inclusi), nell'ordine
+// di GetAllProjectVersions. Se from segue to, i due estremi vengono scambiati.
+func VersionsBetween(versions []Version, from, to *Version) ([]Version, error) {
+	fromIdx, toIdx := -1, -1
+	for i, v := range versions {
+		if v.ID == from.ID {
+			fromIdx = i
+		}
+		if v.ID == to.ID {
+			toIdx = i
+		}
+	}
 
-	endpoint := fmt.Sprintf("/rest/api/3/search/jql?%s", params.Encode())
+	if fromIdx < 0 || toIdx < 0 {
+		return nil, fmt.Errorf("le versioni %s e %s devono appartenere allo stesso progetto", from.Name, to.Name)
+	}
+	if fromIdx > toIdx {
+		fromIdx, toIdx = toIdx, fromIdx
+	}
 
-	var searchResults SearchResults
-	if err := client.GetJSON(endpoint, &searchResults); err != nil {
-		fmt.Println(" ❌")
+	return versions[fromIdx : toIdx+1], nil
+}
+
+// GetIssuesForVersions recupera i ticket di più versioni, restituendoli nello stesso ordine.
+// Un ticket con più fixVersion viene assegnato solo alla prima versione in cui compare.
+func GetIssuesForVersions(ctx context.Context, client *Client, projectKey string, versions []Version, filter IssueFilter) ([][]Issue, error) {
+	seen := make(map[string]bool)
+	result := make([][]Issue, len(versions))
+
+	for i, version := range versions {
+		fmt.Fprintf(os.Stderr, "📦 Versione %s\n", version.Name)
+		issues, err := GetIssuesForVersion(ctx, client, projectKey, version.Name, filter)
+		if err != nil {
+			return nil, fmt.Errorf("versione %s: %w", version.Name, err)
+		}
+
+		for _, issue := range issues {
+			if seen[issue.Key] {
+				continue
+			}
+			seen[issue.Key] = true
+			result[i] = append(result[i], issue)
+		}
+	}
+
+	return result, nil
+}
+
+// GetIssuesForVersion recupera tutti i ticket per una versione specifica usando la ricerca JQL
+// (/search/jql su Cloud, /search su Server), seguendo la paginazione di ogni ricerca.
+// Il filtro determina quali stati includere e può aggiungere clausole JQL.
+func GetIssuesForVersion(ctx context.Context, client *Client, projectKey string, versionName string, filter IssueFilter) ([]Issue, error) {
+	fmt.Fprint(os.Stderr, "⏳ Recupero ticket in rilascio...")
+
+	subtaskTypes := strings.Join(wrapKeys(client.SubtaskTypes), ", ")
+
+	// JQL per trovare tutte le issue nella versione specificata, secondo il filtro
+	jql := fmt.Sprintf(`project = "%s" AND fixVersion = "%s"%s AND issuetype not in (%s)`, projectKey, versionName, filter.clauses(), subtaskTypes)
+
+	results, err := SearchIssues(ctx, client, jql, issueFields)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, " ❌")
 		return nil, fmt.Errorf("errore nella ricerca JQL: %w", err)
 	}
-	fmt.Print(" ✓\n")
+	fmt.Fprint(os.Stderr, " ✓\n")
 
 	var allIssues []Issue
 	issueMap := make(map[string]*Issue)
 	epicKeysInRelease := make(map[string]bool) // Epic che sono direttamente nella release
 
 	// Prima passata: identifica gli Epic nella release e aggiungi tutte le issue
-	for _, issue := range searchResults.Issues {
+	for _, issue := range results {
 		issueCopy := issue
 		allIssues = append(allIssues, issueCopy)
 		issueMap[issue.Key] = &issueCopy
@@ -100,117 +205,140 @@ func GetIssuesForVersion(client *Client, projectKey string, versionName string)
 		}
 	}
 
-	// Recupera i sub-task per le issue nella release
-	fmt.Print("⏳ Recupero sub-task...")
-	subtaskCount := 0
-	for _, issue := range searchResults.Issues {
-		if len(issue.Fields.Subtasks) > 0 {
-			for _, subtaskRef := range issue.Fields.Subtasks {
-				if _, exists := issueMap[subtaskRef.Key]; exists {
-					continue // già presente
-				}
-
-				subtask, err := GetIssue(client, subtaskRef.Key)
-				if err != nil {
-					continue
-				}
-
-				if subtask.IsCompleted() {
-					continue
-				}
-
-				allIssues = append(allIssues, *subtask)
-				issueMap[subtask.Key] = subtask
-				subtaskCount++
-			}
-		}
-	}
-	fmt.Printf(" ✓ (%d trovati)\n", subtaskCount)
-
 	// Recupera le Story/Task che appartengono agli Epic nella release
 	if len(epicKeysInRelease) > 0 {
-		fmt.Print("⏳ Recupero story collegate agli epic...")
+		fmt.Fprint(os.Stderr, "⏳ Recupero story collegate agli epic...")
 
 		epicKeys := make([]string, 0, len(epicKeysInRelease))
 		for key := range epicKeysInRelease {
 			epicKeys = append(epicKeys, key)
 		}
 
-		epicJQL := fmt.Sprintf(`project = "%s" AND statusCategory != Done AND "Epic Link" in (%s)`, projectKey, strings.Join(wrapKeys(epicKeys), ","))
-
-		params := url.Values{}
-		params.Add("jql", epicJQL)
-		params.Add("startAt", "0")
-		params.Add("maxResults", "100")
-		params.Add("fields", "summary,status,assignee,priority,issuetype,parent,subtasks,epic,labels")
+		epicJQL := fmt.Sprintf(`project = "%s"%s AND "Epic Link" in (%s)`, projectKey, filter.clauses(), strings.Join(wrapKeys(epicKeys), ","))
 
-		epicEndpoint := fmt.Sprintf("/rest/api/3/search/jql?%s", params.Encode())
+		epicResults, err := SearchIssues(ctx, client, epicJQL, issueFields)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, " ❌")
+			return nil, fmt.Errorf("errore nella ricerca delle story collegate agli epic: %w", err)
+		}
 
-		var epicResults SearchResults
 		storyCount := 0
-		if err := client.GetJSON(epicEndpoint, &epicResults); err == nil {
-			for _, story := range epicResults.Issues {
-				if _, exists := issueMap[story.Key]; !exists {
-					storyCopy := story
-					allIssues = append(allIssues, storyCopy)
-					issueMap[story.Key] = &storyCopy
-					storyCount++
-
-					// Recupera i sub-task della story
-					if len(story.Fields.Subtasks) > 0 {
-						for _, subtaskRef := range story.Fields.Subtasks {
-							if _, exists := issueMap[subtaskRef.Key]; exists {
-								continue
-							}
-
-							subtask, err := GetIssue(client, subtaskRef.Key)
-							if err != nil {
-								continue
-							}
-
-							if subtask.IsCompleted() {
-								continue
-							}
-
-							allIssues = append(allIssues, *subtask)
-							issueMap[subtask.Key] = subtask
-						}
-					}
-				}
+		for _, story := range epicResults {
+			if _, exists := issueMap[story.Key]; !exists {
+				storyCopy := story
+				allIssues = append(allIssues, storyCopy)
+				issueMap[story.Key] = &storyCopy
+				storyCount++
 			}
 		}
-		fmt.Printf(" ✓ (%d trovate)\n", storyCount)
+		fmt.Fprintf(os.Stderr, " ✓ (%d trovate)\n", storyCount)
 	}
 
-	fmt.Print("⏳ Recupero sub-task 'orfani' (con fixVersion)...")
-	orphanJQL := fmt.Sprintf(`project = "%s" AND fixVersion = "%s" AND statusCategory != Done AND issuetype in (Sub-task, Sub-bug)`, projectKey, versionName)
+	// Recupera in parallelo i sub-task delle issue e delle story raccolte finora
+	var subtaskKeys []string
+	requested := make(map[string]bool)
+	for _, issue := range allIssues {
+		for _, subtaskRef := range issue.Fields.Subtasks {
+			if _, exists := issueMap[subtaskRef.Key]; exists || requested[subtaskRef.Key] {
+				continue // già presente
+			}
+			requested[subtaskRef.Key] = true
+			subtaskKeys = append(subtaskKeys, subtaskRef.Key)
+		}
+	}
 
-	orphanParams := url.Values{}
-	orphanParams.Add("jql", orphanJQL)
-	orphanParams.Add("startAt", "0")
-	orphanParams.Add("maxResults", "100")
-	orphanParams.Add("fields", "summary,status,assignee,priority,issuetype,parent,epic,labels")
+	fmt.Fprint(os.Stderr, "⏳ Recupero sub-task...")
+	subtasks, failures := GetIssues(ctx, client, subtaskKeys)
+	// I sub-task sono recuperati singolarmente: le clausole JQL del filtro vanno verificate con una ricerca
+	jqlMatches, err := matchingKeys(ctx, client, subtasks, filter)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, " ❌")
+		return nil, fmt.Errorf("errore nel filtro JQL dei sub-task: %w", err)
+	}
+	subtaskCount := 0
+	for i := range subtasks {
+		subtask := subtasks[i]
+		if !filter.Matches(&subtask) || (jqlMatches != nil && !jqlMatches[subtask.Key]) {
+			continue
+		}
 
-	orphanEndpoint := fmt.Sprintf("/rest/api/3/search/jql?%s", orphanParams.Encode())
+		allIssues = append(allIssues, subtask)
+		issueMap[subtask.Key] = &subtask
+		subtaskCount++
+	}
+	if len(failures) > 0 {
+		fmt.Fprintf(os.Stderr, " ⚠️  (%d trovati, %d non recuperati)\n", subtaskCount, len(failures))
+	} else {
+		fmt.Fprintf(os.Stderr, " ✓ (%d trovati)\n", subtaskCount)
+	}
+
+	fmt.Fprint(os.Stderr, "⏳ Recupero sub-task 'orfani' (con fixVersion)...")
+	orphanJQL := fmt.Sprintf(`project = "%s" AND fixVersion = "%s"%s AND issuetype in (%s)`, projectKey, versionName, filter.clauses(), subtaskTypes)
+
+	orphanResults, err := SearchIssues(ctx, client, orphanJQL, "summary,status,assignee,priority,issuetype,parent,epic,labels,components,fixVersions")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, " ❌")
+		return nil, fmt.Errorf("errore nella ricerca dei sub-task orfani: %w", err)
+	}
 
-	var orphanResults SearchResults
 	orphanCount := 0
-	if err := client.GetJSON(orphanEndpoint, &orphanResults); err == nil {
-		for _, subtask := range orphanResults.Issues {
-			if _, exists := issueMap[subtask.Key]; !exists {
-				subtaskCopy := subtask
-				allIssues = append(allIssues, subtaskCopy)
-				issueMap[subtask.Key] = &subtaskCopy
-				orphanCount++
-			}
+	for _, subtask := range orphanResults {
+		if _, exists := issueMap[subtask.Key]; !exists {
+			subtaskCopy := subtask
+			allIssues = append(allIssues, subtaskCopy)
+			issueMap[subtask.Key] = &subtaskCopy
+			orphanCount++
 		}
 	}
-	fmt.Printf(" ✓ (%d trovati)\n", orphanCount)
+	fmt.Fprintf(os.Stderr, " ✓ (%d trovati)\n", orphanCount)
+
+	// I sub-task non recuperati sono solo segnalati: un contesto cancellato non deve produrre risultati parziali
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("recupero ticket interrotto: %w", err)
+	}
 
-	fmt.Println()
+	// Riepilogo dei sub-task che non è stato possibile recuperare
+	if len(failures) > 0 {
+		fmt.Fprintf(os.Stderr, "⚠️  %d sub-task non recuperati:\n", len(failures))
+		for _, failure := range failures {
+			fmt.Fprintf(os.Stderr, "   - %s\n", failure.Error())
+		}
+	}
+
+	fmt.Fprintln(os.Stderr)
 	return allIssues, nil
 }
 
+// keyBatchSize è il numero massimo di chiavi in una clausola "key in (...)", per contenere la lunghezza della JQL
+const keyBatchSize = 100
+
+// matchingKeys restituisce le chiavi dei ticket che rispettano le clausole JQL aggiuntive del filtro,
+// con una ricerca "key in (...)" ogni keyBatchSize ticket. Restituisce nil se il filtro non ha clausole JQL.
+func matchingKeys(ctx context.Context, client *Client, issues []Issue, filter IssueFilter) (map[string]bool, error) {
+	jql := strings.TrimSpace(filter.JQL)
+	if jql == "" {
+		return nil, nil
+	}
+
+	matches := make(map[string]bool)
+	for start := 0; start < len(issues); start += keyBatchSize {
+		batch := issues[start:min(start+keyBatchSize, len(issues))]
+		keys := make([]string, len(batch))
+		for i, issue := range batch {
+			keys[i] = issue.Key
+		}
+
+		results, err := SearchIssues(ctx, client, fmt.Sprintf(`key in (%s) AND (%s)`, strings.Join(wrapKeys(keys), ","), jql), "key")
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range results {
+			matches[issue.Key] = true
+		}
+	}
+	return matches, nil
+}
+
 // wrapKeys avvolge le chiavi con virgolette per la JQL
 func wrapKeys(keys []string) []string {
 	wrapped := make([]string, len(keys))
@@ -221,13 +349,86 @@ func wrapKeys(keys []string) []string {
 }
 
 // GetIssue recupera un singolo ticket tramite la sua chiave
-func GetIssue(client *Client, issueKey string) (*Issue, error) {
-	endpoint := fmt.Sprintf("/rest/api/3/issue/%s?fields=summary,status,assignee,priority,labels,issuetype,parent,epic", issueKey)
+func GetIssue(ctx context.Context, client *Client, issueKey string) (*Issue, error) {
+	fields := client.withEpicLink("summary,status,assignee,priority,labels,components,fixVersions,issuetype,parent,epic")
+	endpoint := client.apiPath("/issue/%s?fields=%s", issueKey, fields)
 
-	var issue Issue
-	if err := client.GetJSON(endpoint, &issue); err != nil {
+	var raw json.RawMessage
+	if err := client.GetJSON(ctx, endpoint, &raw); err != nil {
 		return nil, fmt.Errorf("impossibile recuperare il ticket %s: %w", issueKey, err)
 	}
 
+	var issue Issue
+	if err := json.Unmarshal(raw, &issue); err != nil {
+		return nil, fmt.Errorf("impossibile recuperare il ticket %s: errore nel parsing JSON: %w", issueKey, err)
+	}
+	client.applyEpicLink(raw, &issue)
+
 	return &issue, nil
 }
+
+// IssueError associa un errore al ticket che non è stato possibile recuperare
+type IssueError struct {
+	Key string
+	Err error
+}
+
+// Error restituisce una descrizione leggibile dell'errore
+func (e *IssueError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Key, e.Err)
+}
+
+// Unwrap restituisce l'errore originale
+func (e *IssueError) Unwrap() error {
+	return e.Err
+}
+
+// GetIssues recupera più ticket in parallelo, con al massimo client.Concurrency richieste contemporanee.
+// Restituisce i ticket recuperati nell'ordine delle chiavi e un errore per ciascun ticket mancante.
+func GetIssues(ctx context.Context, client *Client, keys []string) ([]Issue, []*IssueError) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	results := make([]*Issue, len(keys))
+	errs := make([]error, len(keys))
+	ForEachConcurrent(len(keys), client.Concurrency, func(i int) {
+		results[i], errs[i] = GetIssue(ctx, client, keys[i])
+	})
+
+	var issues []Issue
+	var failures []*IssueError
+	for i, key := range keys {
+		if errs[i] != nil {
+			failures = append(failures, &IssueError{Key: key, Err: errs[i]})
+			continue
+		}
+		issues = append(issues, *results[i])
+	}
+
+	return issues, failures
+}
+
+// ForEachConcurrent esegue fn per gli indici da 0 a n-1 con al massimo workers esecuzioni contemporanee
+// (almeno una) e attende che terminino tutte. fn deve scrivere solo nella posizione i dei risultati.
+func ForEachConcurrent(n, workers int, fn func(i int)) {
+	workers = max(min(workers, n), 1)
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := range n {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
@@ -0,0 +1,257 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestVersionsBetween(t *testing.T) {
+	versions := []Version{
+		{ID: "1", Name: "2.2.0"},
+		{ID: "2", Name: "2.3.0"},
+		{ID: "3", Name: "2.4.0"},
+		{ID: "4", Name: "2.5.0"},
+	}
+	names := func(vs []Version) []string {
+		var out []string
+		for _, v := range vs {
+			out = append(out, v.Name)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name     string
+		from, to Version
+		want     []string
+	}{
+		{"intervallo incluso", versions[1], versions[3], []string{"2.3.0", "2.4.0", "2.5.0"}},
+		{"estremi invertiti", versions[2], versions[0], []string{"2.2.0", "2.3.0", "2.4.0"}},
+		{"stessa versione", versions[1], versions[1], []string{"2.3.0"}},
+	}
+	for _, tt := range tests {
+		got, err := VersionsBetween(versions, &tt.from, &tt.to)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(names(got), tt.want) {
+			t.Errorf("%s: versioni %v, attese %v", tt.name, names(got), tt.want)
+		}
+	}
+
+	other := Version{ID: "99", Name: "9.9.9"}
+	if _, err := VersionsBetween(versions, &versions[0], &other); err == nil {
+		t.Error("versione di un altro progetto: errore atteso")
+	}
+}
+
+func TestGetIssuesForVersionsDeduplicates(t *testing.T) {
+	// PROJ-2 ha entrambe le fixVersion: deve comparire solo nella prima versione dell'intervallo
+	byVersion := map[string][]string{
+		"2.3.0": {"PROJ-1", "PROJ-2"},
+		"2.4.0": {"PROJ-2", "PROJ-3"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jql := r.URL.Query().Get("jql")
+		var issues []Issue
+		// Le ricerche dei sub-task orfani non restituiscono nulla
+		if !strings.Contains(jql, "issuetype in") {
+			for version, keys := range byVersion {
+				if strings.Contains(jql, `fixVersion = "`+version+`"`) {
+					for _, key := range keys {
+						issues = append(issues, Issue{Key: key, Fields: IssueFields{IssueType: IssueType{Name: "Story"}}})
+					}
+				}
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"issues": issues, "isLast": true})
+	}))
+	defer server.Close()
+
+	versions := []Version{{ID: "2", Name: "2.3.0"}, {ID: "3", Name: "2.4.0"}}
+	result, err := GetIssuesForVersions(context.Background(), newTestClient(server), "PROJ", versions, IssueFilter{Status: StatusAll})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got [][]string
+	for _, issues := range result {
+		var keys []string
+		for _, issue := range issues {
+			keys = append(keys, issue.Key)
+		}
+		got = append(got, keys)
+	}
+	want := [][]string{{"PROJ-1", "PROJ-2"}, {"PROJ-3"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ticket per versione %v, attesi %v", got, want)
+	}
+}
+
+func TestGetIssuesPartialFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/rest/api/3/issue/")
+		if key == "PROJ-2" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorMessages":["Il ticket non esiste"]}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(Issue{Key: key})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	client.Concurrency = 2
+	issues, failures := GetIssues(context.Background(), client, []string{"PROJ-1", "PROJ-2", "PROJ-3"})
+
+	var keys []string
+	for _, issue := range issues {
+		keys = append(keys, issue.Key)
+	}
+	if !reflect.DeepEqual(keys, []string{"PROJ-1", "PROJ-3"}) {
+		t.Errorf("ticket recuperati %v, attesi PROJ-1 e PROJ-3", keys)
+	}
+	if len(failures) != 1 || failures[0].Key != "PROJ-2" || !IsNotFound(failures[0].Err) {
+		t.Fatalf("errori %v, atteso un 404 per PROJ-2", failures)
+	}
+}
+
+func TestLatestReleased(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []Version
+		want     string
+	}{
+		{
+			"versione rilasciata senza data ignorata",
+			[]Version{
+				{Name: "2.2.0", Released: true, ReleaseDate: "2026-08-01"},
+				{Name: "2.3.0", Released: true, ReleaseDate: "2026-09-01"},
+				{Name: "2.4.0", ReleaseDate: "2026-10-01"},
+				{Name: "legacy", Released: true},
+			},
+			"2.3.0",
+		},
+		{
+			"versione archiviata ignorata",
+			[]Version{
+				{Name: "2.2.0", Released: true, ReleaseDate: "2026-08-01"},
+				{Name: "2.3.0", Released: true, Archived: true, ReleaseDate: "2026-09-01"},
+			},
+			"2.2.0",
+		},
+		{
+			"nessuna versione con data",
+			[]Version{{Name: "1.0", Released: true}, {Name: "1.1", Released: true}, {Name: "2.0"}},
+			"1.1",
+		},
+		{"nessuna versione rilasciata", []Version{{Name: "2.4.0", ReleaseDate: "2026-10-01"}}, ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if v := latestReleased(tt.versions); v != nil {
+			got = v.Name
+		}
+		if got != tt.want {
+			t.Errorf("%s: versione %q, attesa %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGetIssuesForVersionSecondarySearchErrors(t *testing.T) {
+	epic := Issue{Key: "PROJ-1", Fields: IssueFields{IssueType: IssueType{Name: "Epic"}}}
+
+	tests := []struct {
+		name   string
+		failOn string // Frammento della JQL la cui seconda pagina fallisce
+	}{
+		{"story collegate agli epic", `"Epic Link" in`},
+		{"sub-task orfani", "issuetype in ("},
+	}
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			jql := r.URL.Query().Get("jql")
+			switch {
+			case strings.Contains(jql, tt.failOn) && r.URL.Query().Get("nextPageToken") != "":
+				http.Error(w, `{"errorMessages":["errore interno"]}`, http.StatusInternalServerError)
+			case strings.Contains(jql, tt.failOn):
+				_ = json.NewEncoder(w).Encode(SearchResults{Issues: makeIssues(10, 100), NextPageToken: "page-2"})
+			case strings.Contains(jql, "issuetype not in"):
+				_ = json.NewEncoder(w).Encode(SearchResults{Issues: []Issue{epic}, IsLast: true})
+			default:
+				_ = json.NewEncoder(w).Encode(SearchResults{IsLast: true})
+			}
+		}))
+
+		_, err := GetIssuesForVersion(context.Background(), newTestClient(server), "PROJ", "2.4.0", IssueFilter{Status: StatusAll})
+		server.Close()
+		if !hasStatus(err, http.StatusInternalServerError) {
+			t.Errorf("%s: errore della seconda pagina non restituito: %v", tt.name, err)
+		}
+	}
+}
+
+func TestGetIssuesForVersionFiltersSubtasksByJQL(t *testing.T) {
+	story := Issue{Key: "PROJ-1", Fields: IssueFields{
+		IssueType: IssueType{Name: "Story"},
+		Subtasks:  []IssueRef{{Key: "PROJ-2"}, {Key: "PROJ-3"}},
+	}}
+	var keyJQL string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key, ok := strings.CutPrefix(r.URL.Path, "/rest/api/3/issue/"); ok {
+			_ = json.NewEncoder(w).Encode(Issue{Key: key, Fields: IssueFields{IssueType: IssueType{Name: "Sub-task"}}})
+			return
+		}
+		jql := r.URL.Query().Get("jql")
+		switch {
+		case strings.HasPrefix(jql, "key in"):
+			// Solo PROJ-2 rispetta il filtro component = API
+			keyJQL = jql
+			_ = json.NewEncoder(w).Encode(SearchResults{Issues: []Issue{{Key: "PROJ-2"}}, IsLast: true})
+		case strings.Contains(jql, "issuetype not in"):
+			_ = json.NewEncoder(w).Encode(SearchResults{Issues: []Issue{story}, IsLast: true})
+		default:
+			_ = json.NewEncoder(w).Encode(SearchResults{IsLast: true})
+		}
+	}))
+	defer server.Close()
+
+	filter := IssueFilter{Status: StatusAll, JQL: "component = API"}
+	issues, err := GetIssuesForVersion(context.Background(), newTestClient(server), "PROJ", "2.4.0", filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []string
+	for _, issue := range issues {
+		keys = append(keys, issue.Key)
+	}
+	if !reflect.DeepEqual(keys, []string{"PROJ-1", "PROJ-2"}) {
+		t.Errorf("ticket %v, attesi PROJ-1 e PROJ-2", keys)
+	}
+	if want := `key in ("PROJ-2","PROJ-3") AND (component = API)`; keyJQL != want {
+		t.Errorf("JQL dei sub-task %q, attesa %q", keyJQL, want)
+	}
+}
+
+func TestLatestDated(t *testing.T) {
+	// Ordine di GetAllProjectVersions: le versioni senza data sono in coda
+	versions := []Version{
+		{Name: "2.3.0", ReleaseDate: "2026-09-01"},
+		{Name: "2.4.0", ReleaseDate: "2026-10-01"},
+		{Name: "2.9.0"},
+	}
+	if got := LatestDated(versions); got == nil || got.Name != "2.4.0" {
+		t.Errorf("LatestDated = %v, attesa 2.4.0", got)
+	}
+	if got := LatestDated(versions[2:]); got != nil {
+		t.Errorf("LatestDated senza date = %v, atteso nil", got)
+	}
+}
@@ -0,0 +1,101 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// VersionCreate contiene i dati per la creazione di una versione
+type VersionCreate struct {
+	Name        string `json:"name"`
+	ProjectID   int64  `json:"projectId"`
+	Description string `json:"description,omitempty"`
+	StartDate   string `json:"startDate,omitempty"`
+	ReleaseDate string `json:"releaseDate,omitempty"`
+}
+
+// VersionUpdate contiene i campi da modificare su una versione; i campi nil o vuoti non vengono toccati
+type VersionUpdate struct {
+	Name        string  `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	StartDate   string  `json:"startDate,omitempty"`
+	ReleaseDate string  `json:"releaseDate,omitempty"`
+	Released    *bool   `json:"released,omitempty"`
+	Archived    *bool   `json:"archived,omitempty"`
+	// MoveUnfixedIssuesTo è l'URL (self) della versione verso cui spostare i ticket non risolti al rilascio
+	MoveUnfixedIssuesTo string `json:"moveUnfixedIssuesTo,omitempty"`
+}
+
+// GetProject recupera i dati di base di un progetto
+func GetProject(ctx context.Context, client *Client, projectKey string) (*Project, error) {
+	endpoint := client.apiPath("/project/%s", projectKey)
+
+	var project Project
+	if err := client.GetJSON(ctx, endpoint, &project); err != nil {
+		return nil, fmt.Errorf("impossibile recuperare il progetto %s: %w", projectKey, err)
+	}
+
+	return &project, nil
+}
+
+// CreateVersion crea una nuova versione nel progetto indicato
+func CreateVersion(ctx context.Context, client *Client, projectKey string, data VersionCreate) (*Version, error) {
+	project, err := GetProject(ctx, client, projectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	data.ProjectID, err = strconv.ParseInt(project.ID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("id del progetto %s non valido: %q", projectKey, project.ID)
+	}
+
+	var version Version
+	if err := client.PostJSON(ctx, client.apiPath("/version"), data, &version); err != nil {
+		return nil, fmt.Errorf("impossibile creare la versione %s: %w", data.Name, err)
+	}
+
+	return &version, nil
+}
+
+// UpdateVersion modifica una versione esistente
+func UpdateVersion(ctx context.Context, client *Client, versionID string, data VersionUpdate) (*Version, error) {
+	endpoint := client.apiPath("/version/%s", versionID)
+
+	var version Version
+	if err := client.PutJSON(ctx, endpoint, data, &version); err != nil {
+		return nil, fmt.Errorf("impossibile aggiornare la versione %s: %w", versionID, err)
+	}
+
+	return &version, nil
+}
+
+// ReleaseVersion segna una versione come rilasciata alla data indicata. Se moveTo non è nil,
+// i ticket non risolti vengono spostati su quella versione, come avviene dalla UI di Jira.
+func ReleaseVersion(ctx context.Context, client *Client, version *Version, releaseDate string, moveTo *Version) (*Version, error) {
+	released := true
+	data := VersionUpdate{
+		Released:    &released,
+		ReleaseDate: releaseDate,
+	}
+	if moveTo != nil {
+		data.MoveUnfixedIssuesTo = versionSelf(client, moveTo)
+	}
+
+	return UpdateVersion(ctx, client, version.ID, data)
+}
+
+// ArchiveVersion archivia una versione
+func ArchiveVersion(ctx context.Context, client *Client, version *Version) (*Version, error) {
+	archived := true
+	return UpdateVersion(ctx, client, version.ID, VersionUpdate{Archived: &archived})
+}
+
+// versionSelf restituisce l'URL self di una versione, ricostruendolo se assente
+func versionSelf(client *Client, version *Version) string {
+	if version.Self != "" {
+		return version.Self
+	}
+	return client.BaseURL + client.apiPath("/version/%s", version.ID)
+}
@@ -0,0 +1,123 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// versionRequest è una richiesta ricevuta dal server di test delle versioni
+type versionRequest struct {
+	method string
+	path   string
+	body   map[string]interface{}
+}
+
+// newVersionServer simula le API di progetto e versioni, registrando le richieste di modifica
+func newVersionServer(t *testing.T, requests *[]versionRequest) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			if r.URL.Path != "/rest/api/3/project/PROJ" {
+				t.Errorf("richiesta inattesa: %s %s", r.Method, r.URL.Path)
+			}
+			_, _ = w.Write([]byte(`{"id":"10000","key":"PROJ"}`))
+			return
+		}
+
+		request := versionRequest{method: r.Method, path: r.URL.Path}
+		if err := json.NewDecoder(r.Body).Decode(&request.body); err != nil {
+			t.Errorf("payload non valido: %v", err)
+		}
+		*requests = append(*requests, request)
+		_, _ = w.Write([]byte(`{"id":"10042","name":"2.4.0"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCreateVersion(t *testing.T) {
+	var requests []versionRequest
+	client := newTestClient(newVersionServer(t, &requests))
+
+	data := VersionCreate{Name: "2.4.0", Description: "Login", ReleaseDate: "2026-10-12"}
+	version, err := CreateVersion(context.Background(), client, "PROJ", data)
+	if err != nil || version.ID != "10042" {
+		t.Fatalf("version=%+v err=%v", version, err)
+	}
+
+	want := versionRequest{
+		method: http.MethodPost,
+		path:   "/rest/api/3/version",
+		body:   map[string]interface{}{"name": "2.4.0", "projectId": float64(10000), "description": "Login", "releaseDate": "2026-10-12"},
+	}
+	if len(requests) != 1 || !reflect.DeepEqual(requests[0], want) {
+		t.Errorf("richieste %+v, attesa %+v", requests, want)
+	}
+}
+
+func TestUpdateVersions(t *testing.T) {
+	description := ""
+	released := &Version{ID: "10042", Name: "2.4.0"}
+	next := &Version{ID: "10043", Name: "2.5.0"}
+	nextWithSelf := &Version{ID: "10043", Name: "2.5.0", Self: "https://jira.example.com/rest/api/3/version/10043"}
+
+	tests := []struct {
+		name string
+		run  func(*Client) (*Version, error)
+		want map[string]interface{}
+	}{
+		{
+			"modifica con descrizione svuotata",
+			func(c *Client) (*Version, error) {
+				return UpdateVersion(context.Background(), c, "10042", VersionUpdate{Name: "2.4.1", Description: &description})
+			},
+			map[string]interface{}{"name": "2.4.1", "description": ""},
+		},
+		{
+			"rilascio",
+			func(c *Client) (*Version, error) {
+				return ReleaseVersion(context.Background(), c, released, "2026-10-12", nil)
+			},
+			map[string]interface{}{"released": true, "releaseDate": "2026-10-12"},
+		},
+		{
+			"rilascio con spostamento verso l'URL self",
+			func(c *Client) (*Version, error) {
+				return ReleaseVersion(context.Background(), c, released, "2026-10-12", nextWithSelf)
+			},
+			map[string]interface{}{"released": true, "releaseDate": "2026-10-12", "moveUnfixedIssuesTo": "https://jira.example.com/rest/api/3/version/10043"},
+		},
+		{
+			"archiviazione",
+			func(c *Client) (*Version, error) {
+				return ArchiveVersion(context.Background(), c, released)
+			},
+			map[string]interface{}{"archived": true},
+		},
+	}
+	for _, tt := range tests {
+		var requests []versionRequest
+		client := newTestClient(newVersionServer(t, &requests))
+		if _, err := tt.run(client); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		want := versionRequest{method: http.MethodPut, path: "/rest/api/3/version/10042", body: tt.want}
+		if len(requests) != 1 || !reflect.DeepEqual(requests[0], want) {
+			t.Errorf("%s: richieste %+v, attesa %+v", tt.name, requests, want)
+		}
+	}
+
+	// Senza self l'URL della versione di destinazione viene ricostruito dall'indirizzo del client
+	var requests []versionRequest
+	server := newVersionServer(t, &requests)
+	if _, err := ReleaseVersion(context.Background(), newTestClient(server), released, "2026-10-12", next); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := requests[0].body["moveUnfixedIssuesTo"], server.URL+"/rest/api/3/version/10043"; got != want {
+		t.Errorf("moveUnfixedIssuesTo = %v, atteso %s", got, want)
+	}
+}
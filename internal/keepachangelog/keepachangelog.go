@@ -0,0 +1,255 @@
+package keepachangelog
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"jira-release-manager/internal/jira"
+)
+
+// Sezioni previste da Keep a Changelog (https://keepachangelog.com), nell'ordine in cui vengono scritte
+var Sections = []string{"Added", "Changed", "Deprecated", "Removed", "Fixed", "Security"}
+
+// Skip è il valore di mapping che esclude un tipo di issue dal changelog
+const Skip = "-"
+
+// defaultSection è la sezione usata per i tipi di issue non presenti nel mapping
+const defaultSection = "Changed"
+
+// Header è l'intestazione usata quando il file CHANGELOG.md non esiste ancora
+const Header = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
+`
+
+// UnreleasedDate è la data scritta nell'intestazione delle versioni senza data di rilascio:
+// resta la stessa tra un'esecuzione e l'altra, così la sezione non cambia finché la versione non esce
+const UnreleasedDate = "Unreleased"
+
+// Mapping associa un tipo di issue Jira a una sezione del changelog
+type Mapping map[string]string
+
+// DefaultMapping restituisce il mapping predefinito. Gli epic sono esclusi perché
+// le loro story compaiono già singolarmente.
+func DefaultMapping() Mapping {
+	return Mapping{
+		"Epic":          Skip,
+		"Story":         "Added",
+		"New Feature":   "Added",
+		"Feature":       "Added",
+		"Task":          "Changed",
+		"Improvement":   "Changed",
+		"Bug":           "Fixed",
+		"Security":      "Security",
+		"Vulnerability": "Security",
+	}
+}
+
+// ParseMapping applica al mapping predefinito le associazioni nel formato "Tipo=Sezione,Tipo=Sezione".
+// Il confronto con i nomi delle sezioni non distingue maiuscole e minuscole.
+func ParseMapping(spec string) (Mapping, error) {
+	mapping := DefaultMapping()
+	for _, pair := range strings.Split(spec, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		issueType, section, ok := strings.Cut(pair, "=")
+		issueType, section = strings.TrimSpace(issueType), strings.TrimSpace(section)
+		if !ok || issueType == "" || section == "" {
+			return nil, fmt.Errorf("mapping non valido '%s': usa il formato Tipo=Sezione", pair)
+		}
+
+		canonical, err := canonicalSection(section)
+		if err != nil {
+			return nil, err
+		}
+		mapping[issueType] = canonical
+	}
+	return mapping, nil
+}
+
+// canonicalSection normalizza il nome di una sezione (es. "fixed" → "Fixed")
+func canonicalSection(section string) (string, error) {
+	if section == Skip {
+		return Skip, nil
+	}
+	for _, s := range Sections {
+		if strings.EqualFold(s, section) {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("sezione non valida '%s': valori ammessi %s o %s per escludere il tipo", section, strings.Join(Sections, ", "), Skip)
+}
+
+// SectionFor restituisce la sezione di un tipo di issue, o Skip se il tipo va escluso
+func (m Mapping) SectionFor(issueType string) string {
+	if section, ok := m[issueType]; ok {
+		return section
+	}
+	for name, section := range m {
+		if strings.EqualFold(name, issueType) {
+			return section
+		}
+	}
+	return defaultSection
+}
+
+// Render genera la sezione di una versione, con una voce per ticket raggruppata secondo il mapping.
+// I sub-task sono sempre esclusi.
+func Render(versionName, date string, issues []jira.Issue, mapping Mapping, issueURL func(key string) string) string {
+	grouped := make(map[string][]jira.Issue)
+	for _, issue := range issues {
+		if issue.Fields.IssueType.Subtask {
+			continue
+		}
+		if section := mapping.SectionFor(issue.Fields.IssueType.Name); section != Skip {
+			grouped[section] = append(grouped[section], issue)
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## [%s] - %s\n", versionName, date)
+	for _, section := range Sections {
+		if len(grouped[section]) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n### %s\n\n", section)
+		for _, issue := range grouped[section] {
+			fmt.Fprintf(&sb, "- %s ([%s](%s))\n", strings.TrimSpace(issue.Fields.Summary), issue.Key, issueURL(issue.Key))
+		}
+	}
+	return sb.String()
+}
+
+var (
+	// releaseHeading riconosce l'intestazione di una versione: "## [1.2.0] - 2024-01-01" o "## 1.2.0".
+	// Tra parentesi quadre il nome può contenere spazi (es. "## [Release 2.4]").
+	releaseHeading = regexp.MustCompile(`^##\s+(?:\[([^\]]+)\]|(\S+))`)
+	// linkReference riconosce le definizioni dei link in fondo al file: "[1.2.0]: https://..."
+	linkReference = regexp.MustCompile(`^\[[^\]]+\]:\s`)
+)
+
+// Upsert inserisce la sezione di una versione nel contenuto di un CHANGELOG.md, o la sostituisce
+// se la versione è già presente. Le altre sezioni restano invariate, quindi rieseguire Upsert
+// con la stessa sezione non modifica il file. Le nuove versioni vengono inserite sotto
+// [Unreleased], prima della prima versione esistente più vecchia (secondo compareVersions),
+// così anche le versioni aggiunte a posteriori restano in ordine.
+func Upsert(content, versionName, section string) string {
+	if strings.TrimSpace(content) == "" {
+		content = Header
+	}
+
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	sectionLines := strings.Split(strings.TrimRight(section, "\n"), "\n")
+
+	start, end := -1, len(lines)
+	insertAt := -1
+	for i, line := range lines {
+		if linkReference.MatchString(line) {
+			if insertAt < 0 {
+				insertAt = i
+			}
+			if start >= 0 && end == len(lines) {
+				end = i
+			}
+			continue
+		}
+
+		match := releaseHeading.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		name := match[1] + match[2]
+		if start >= 0 && end == len(lines) {
+			end = i
+		}
+		if name == versionName {
+			start = i
+			end = len(lines)
+		} else if insertAt < 0 && !strings.EqualFold(name, "Unreleased") && compareVersions(name, versionName) < 0 {
+			insertAt = i
+		}
+	}
+
+	var result []string
+	switch {
+	case start >= 0:
+		result = append(result, lines[:start]...)
+		result = append(result, sectionLines...)
+		result = appendRest(result, lines[end:])
+	case insertAt >= 0:
+		result = append(result, trimTrailingBlank(lines[:insertAt])...)
+		result = append(result, "")
+		result = append(result, sectionLines...)
+		result = appendRest(result, lines[insertAt:])
+	default:
+		result = append(result, trimTrailingBlank(lines)...)
+		result = append(result, "")
+		result = append(result, sectionLines...)
+	}
+
+	return strings.Join(result, "\n") + "\n"
+}
+
+// compareVersions confronta due nomi di versione in ordine naturale: le sequenze di cifre sono
+// confrontate come numeri (2.10.0 segue 2.9.0), il resto come testo; un prefisso "v" viene ignorato.
+// Restituisce -1, 0 o 1 se a precede, coincide o segue b.
+func compareVersions(a, b string) int {
+	ca, cb := versionChunks(a), versionChunks(b)
+	for i := 0; i < len(ca) && i < len(cb); i++ {
+		na, errA := strconv.Atoi(ca[i])
+		nb, errB := strconv.Atoi(cb[i])
+		switch {
+		case errA == nil && errB == nil:
+			if na != nb {
+				return cmp.Compare(na, nb)
+			}
+		case ca[i] != cb[i]:
+			return strings.Compare(ca[i], cb[i])
+		}
+	}
+	return cmp.Compare(len(ca), len(cb))
+}
+
+// versionChunks divide un nome di versione in sequenze di cifre e di altri caratteri
+func versionChunks(name string) []string {
+	name = strings.TrimPrefix(strings.TrimPrefix(name, "v"), "V")
+	var chunks []string
+	start := 0
+	for i, r := range name {
+		if i > start && unicode.IsDigit(r) != unicode.IsDigit(rune(name[start])) {
+			chunks = append(chunks, name[start:i])
+			start = i
+		}
+	}
+	if start < len(name) {
+		chunks = append(chunks, name[start:])
+	}
+	return chunks
+}
+
+// appendRest accoda le righe successive alla sezione, separate da una sola riga vuota
+func appendRest(result, rest []string) []string {
+	for len(rest) > 0 && strings.TrimSpace(rest[0]) == "" {
+		rest = rest[1:]
+	}
+	if len(rest) == 0 {
+		return result
+	}
+	return append(append(result, ""), rest...)
+}
+
+// trimTrailingBlank rimuove le righe vuote finali
+func trimTrailingBlank(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
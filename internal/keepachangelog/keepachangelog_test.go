@@ -0,0 +1,162 @@
+package keepachangelog
+
+import (
+	"strings"
+	"testing"
+
+	"jira-release-manager/internal/jira"
+)
+
+const existing = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+## [Unreleased]
+
+- Lavori in corso
+
+## [2.3.0] - 2026-09-01
+
+### Fixed
+
+- Vecchia correzione
+
+[2.3.0]: https://example.com/compare/v2.2.0...v2.3.0
+`
+
+func issueURL(key string) string {
+	return "https://jira.example.com/browse/" + key
+}
+
+func TestUpsertInsertsAndIsIdempotent(t *testing.T) {
+	issues := []jira.Issue{
+		{Key: "PROJ-1", Fields: jira.IssueFields{Summary: "Autenticazione", IssueType: jira.IssueType{Name: "Epic"}}},
+		{Key: "PROJ-2", Fields: jira.IssueFields{Summary: "Login", IssueType: jira.IssueType{Name: "Story"}}},
+		{Key: "PROJ-3", Fields: jira.IssueFields{Summary: "Crash all'avvio", IssueType: jira.IssueType{Name: "Bug"}}},
+	}
+	section := Render("2.4.0", "2026-10-12", issues, DefaultMapping(), issueURL)
+
+	updated := Upsert(existing, "2.4.0", section)
+	want := `## [Unreleased]
+
+- Lavori in corso
+
+## [2.4.0] - 2026-10-12
+
+### Added
+
+- Login ([PROJ-2](https://jira.example.com/browse/PROJ-2))
+
+### Fixed
+
+- Crash all'avvio ([PROJ-3](https://jira.example.com/browse/PROJ-3))
+
+## [2.3.0] - 2026-09-01`
+	if !strings.Contains(updated, want) {
+		t.Fatalf("sezione non inserita correttamente:\n%s", updated)
+	}
+	if !strings.HasSuffix(updated, "[2.3.0]: https://example.com/compare/v2.2.0...v2.3.0\n") {
+		t.Errorf("definizioni dei link non preservate:\n%s", updated)
+	}
+
+	if again := Upsert(updated, "2.4.0", section); again != updated {
+		t.Errorf("Upsert non idempotente:\n%s", again)
+	}
+}
+
+func TestUpsertReplacesExistingVersion(t *testing.T) {
+	section := "## [2.3.0] - 2026-09-02\n\n### Fixed\n\n- Nuova correzione\n"
+
+	updated := Upsert(existing, "2.3.0", section)
+	if strings.Contains(updated, "Vecchia correzione") || !strings.Contains(updated, "Nuova correzione") {
+		t.Fatalf("sezione non sostituita:\n%s", updated)
+	}
+	if !strings.Contains(updated, "- Nuova correzione\n\n[2.3.0]: ") {
+		t.Errorf("separazione dalle definizioni dei link non corretta:\n%s", updated)
+	}
+}
+
+func TestParseMapping(t *testing.T) {
+	mapping, err := ParseMapping("Task=fixed, Spike=-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := mapping.SectionFor("Task"); got != "Fixed" {
+		t.Errorf("Task → %s", got)
+	}
+	if got := mapping.SectionFor("Spike"); got != Skip {
+		t.Errorf("Spike → %s", got)
+	}
+	if got := mapping.SectionFor("Chore"); got != "Changed" {
+		t.Errorf("tipo non mappato → %s", got)
+	}
+
+	if _, err := ParseMapping("Bug=Broken"); err == nil {
+		t.Error("atteso errore per una sezione non valida")
+	}
+}
+
+func TestUpsertVersionNameWithSpaces(t *testing.T) {
+	section := Render("Release 2.4", UnreleasedDate, []jira.Issue{
+		{Key: "PROJ-2", Fields: jira.IssueFields{Summary: "Login", IssueType: jira.IssueType{Name: "Story"}}},
+	}, DefaultMapping(), issueURL)
+
+	updated := Upsert(existing, "Release 2.4", section)
+	again := Upsert(updated, "Release 2.4", section)
+	if again != updated {
+		t.Errorf("Upsert non idempotente:\n%s", again)
+	}
+	if n := strings.Count(again, "## [Release 2.4] - Unreleased"); n != 1 {
+		t.Errorf("%d sezioni [Release 2.4], attesa 1:\n%s", n, again)
+	}
+	if !strings.Contains(again, "## [2.3.0] - 2026-09-01") {
+		t.Errorf("sezione 2.3.0 persa:\n%s", again)
+	}
+}
+
+func TestUpsertKeepsVersionOrder(t *testing.T) {
+	older := "## [2.2.0] - 2026-08-01\n\n### Added\n\n- Esportazione CSV\n"
+	updated := Upsert(existing, "2.2.0", older)
+
+	// La versione più vecchia va sotto 2.3.0, prima delle definizioni dei link
+	want := "- Vecchia correzione\n\n## [2.2.0] - 2026-08-01\n\n### Added\n\n- Esportazione CSV\n\n[2.3.0]: "
+	if !strings.Contains(updated, want) {
+		t.Fatalf("versione più vecchia non inserita in ordine:\n%s", updated)
+	}
+
+	// Un intervallo applicato in qualsiasi ordine produce lo stesso file
+	newer := "## [2.10.0] - 2026-11-01\n\n### Fixed\n\n- Correzione\n"
+	middle := "## [2.4.0] - 2026-10-12\n\n### Fixed\n\n- Altra correzione\n"
+	forward := Upsert(Upsert(updated, "2.4.0", middle), "2.10.0", newer)
+	backward := Upsert(Upsert(updated, "2.10.0", newer), "2.4.0", middle)
+	if forward != backward {
+		t.Errorf("ordine dipendente dall'inserimento:\n%s\n---\n%s", forward, backward)
+	}
+	headings := []string{"## [Unreleased]", "## [2.10.0]", "## [2.4.0]", "## [2.3.0]", "## [2.2.0]"}
+	last := -1
+	for _, heading := range headings {
+		i := strings.Index(forward, heading)
+		if i <= last {
+			t.Fatalf("%s fuori ordine:\n%s", heading, forward)
+		}
+		last = i
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"2.10.0", "2.9.0", 1},
+		{"v2.4.0", "2.4.0", 0},
+		{"2.4.0", "2.4.0.1", -1},
+		{"2.4.0-rc1", "2.4.0-rc2", -1},
+		{"Release 2.4", "Release 2.3", 1},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, atteso %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"jira-release-manager/internal/jira"
+	"jira-release-manager/internal/organizer"
+)
+
+// Check identifica un controllo strutturale sui ticket di una versione
+type Check string
+
+const (
+	// CheckSubtaskVersion: sub-task con fixVersion diverse da quelle del genitore
+	CheckSubtaskVersion Check = "subtask-version"
+	// CheckEpicChildVersion: figli di un epic della versione senza la fixVersion
+	CheckEpicChildVersion Check = "epic-child-version"
+	// CheckMultipleVersions: ticket con più fixVersion
+	CheckMultipleVersions Check = "multiple-versions"
+	// CheckMissingLabels: ticket con la fixVersion ma senza etichette
+	CheckMissingLabels Check = "missing-labels"
+	// CheckOpenEpic: epic aperti con tutti i figli completati
+	CheckOpenEpic Check = "open-epic"
+)
+
+// Checks elenca i controlli nell'ordine in cui vengono mostrati
+var Checks = []Check{CheckSubtaskVersion, CheckEpicChildVersion, CheckMultipleVersions, CheckMissingLabels, CheckOpenEpic}
+
+// Descriptions contiene la descrizione leggibile di ogni controllo
+var Descriptions = map[Check]string{
+	CheckSubtaskVersion:   "Sub-task con fixVersion diverse dal genitore",
+	CheckEpicChildVersion: "Figli di epic senza la fixVersion",
+	CheckMultipleVersions: "Ticket con più fixVersion",
+	CheckMissingLabels:    "Ticket con la fixVersion ma senza etichette",
+	CheckOpenEpic:         "Epic aperti con tutti i figli completati",
+}
+
+// Problem è un problema strutturale trovato su un ticket
+type Problem struct {
+	Check  Check
+	Issue  jira.Issue
+	Detail string
+	Fix    *jira.FixVersionChange // Correzione proposta delle fixVersion, nil se il problema va risolto a mano
+}
+
+// Run esegue i controlli sui ticket di una versione. parents contiene i genitori dei sub-task
+// che non fanno parte della versione, recuperati a parte; i sub-task il cui genitore non è
+// disponibile non vengono controllati. subtaskTypes sono i tipi di issue considerati sub-task
+// oltre a quelli marcati come tali da Jira (JIRA_SUBTASK_TYPES).
+func Run(versionName string, issues []jira.Issue, parents map[string]jira.Issue, subtaskTypes []string) []Problem {
+	issues = sortedByKey(issues)
+	hierarchy := organizer.NewReleaseHierarchy(issues, false)
+
+	byKey := make(map[string]jira.Issue, len(issues)+len(parents))
+	for key, parent := range parents {
+		byKey[key] = parent
+	}
+	for _, issue := range issues {
+		byKey[issue.Key] = issue
+	}
+
+	var problems []Problem
+
+	// Sub-task con fixVersion diverse dal genitore: il sub-task si allinea al genitore.
+	// Un sub-task senza fixVersion eredita quella del genitore e non è un problema.
+	for _, issue := range issues {
+		if !IsSubtask(issue, subtaskTypes) || issue.Fields.Parent == nil || len(issue.Fields.FixVersions) == 0 {
+			continue
+		}
+		parent, ok := byKey[issue.Fields.Parent.Key]
+		if !ok {
+			continue
+		}
+		own, parentVersions := versionNames(issue), versionNames(parent)
+		change := jira.FixVersionChange{Add: difference(parentVersions, own), Remove: difference(own, parentVersions)}
+		if change.IsEmpty() {
+			continue
+		}
+		problems = append(problems, Problem{
+			Check:  CheckSubtaskVersion,
+			Issue:  issue,
+			Detail: fmt.Sprintf("fixVersion %s, il genitore %s ha %s", formatVersions(own), parent.Key, formatVersions(parentVersions)),
+			Fix:    &change,
+		})
+	}
+
+	// Figli di epic senza la fixVersion: si propone di aggiungerla solo a chi non ne ha nessuna,
+	// perché un figlio pianificato su un'altra versione potrebbe esserlo di proposito.
+	for _, epic := range hierarchy.SortedEpics() {
+		for _, child := range hierarchy.EpicChildren[epic.Key] {
+			own := versionNames(child)
+			if slices.Contains(own, versionName) {
+				continue
+			}
+			problem := Problem{Check: CheckEpicChildVersion, Issue: child}
+			if len(own) == 0 {
+				problem.Detail = fmt.Sprintf("figlio di %s senza fixVersion", epic.Key)
+				problem.Fix = &jira.FixVersionChange{Add: []string{versionName}}
+			} else {
+				problem.Detail = fmt.Sprintf("figlio di %s con fixVersion %s", epic.Key, formatVersions(own))
+			}
+			problems = append(problems, problem)
+		}
+	}
+
+	for _, issue := range issues {
+		if own := versionNames(issue); len(own) > 1 {
+			problems = append(problems, Problem{
+				Check:  CheckMultipleVersions,
+				Issue:  issue,
+				Detail: "fixVersion " + formatVersions(own),
+			})
+		}
+	}
+
+	// Gli epic sono esclusi: di norma non corrispondono a un singolo repository
+	for _, issue := range issues {
+		if isEpic(issue) || len(issue.Fields.Labels) > 0 || !slices.Contains(versionNames(issue), versionName) {
+			continue
+		}
+		problems = append(problems, Problem{Check: CheckMissingLabels, Issue: issue, Detail: "nessuna etichetta"})
+	}
+
+	for _, epic := range hierarchy.SortedEpics() {
+		children := hierarchy.EpicChildren[epic.Key]
+		if epic.IsCompleted() || len(children) == 0 {
+			continue
+		}
+		if slices.ContainsFunc(children, func(child jira.Issue) bool { return !child.IsCompleted() }) {
+			continue
+		}
+		problems = append(problems, Problem{
+			Check:  CheckOpenEpic,
+			Issue:  epic,
+			Detail: fmt.Sprintf("%d figli completati, epic in stato %s", len(children), epic.Fields.Status.Name),
+		})
+	}
+
+	return problems
+}
+
+// Fixes raccoglie le correzioni proposte, una per ticket, nell'ordine dei problemi
+func Fixes(problems []Problem) []jira.FixVersionUpdate {
+	var fixes []jira.FixVersionUpdate
+	index := make(map[string]int)
+	for _, problem := range problems {
+		if problem.Fix == nil {
+			continue
+		}
+		i, ok := index[problem.Issue.Key]
+		if !ok {
+			i = len(fixes)
+			index[problem.Issue.Key] = i
+			fixes = append(fixes, jira.FixVersionUpdate{Issue: problem.Issue})
+		}
+		fixes[i].Add = union(fixes[i].Add, problem.Fix.Add)
+		fixes[i].Remove = union(fixes[i].Remove, problem.Fix.Remove)
+	}
+	return fixes
+}
+
+// sortedByKey restituisce una copia dei ticket ordinata per chiave
+func sortedByKey(issues []jira.Issue) []jira.Issue {
+	keys := make([]string, 0, len(issues))
+	byKey := make(map[string]jira.Issue, len(issues))
+	for _, issue := range issues {
+		if _, ok := byKey[issue.Key]; !ok {
+			keys = append(keys, issue.Key)
+		}
+		byKey[issue.Key] = issue
+	}
+	organizer.SortIssueKeys(keys)
+
+	sorted := make([]jira.Issue, 0, len(keys))
+	for _, key := range keys {
+		sorted = append(sorted, byKey[key])
+	}
+	return sorted
+}
+
+// versionNames restituisce i nomi delle fixVersion di un ticket
+func versionNames(issue jira.Issue) []string {
+	names := make([]string, 0, len(issue.Fields.FixVersions))
+	for _, version := range issue.Fields.FixVersions {
+		names = append(names, version.Name)
+	}
+	return names
+}
+
+// formatVersions descrive una lista di versioni
+func formatVersions(names []string) string {
+	if len(names) == 0 {
+		return "nessuna"
+	}
+	return strings.Join(names, ", ")
+}
+
+// difference restituisce gli elementi di a non presenti in b
+func difference(a, b []string) []string {
+	var out []string
+	for _, item := range a {
+		if !slices.Contains(b, item) {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// union aggiunge ad a gli elementi di b non ancora presenti
+func union(a, b []string) []string {
+	for _, item := range b {
+		if !slices.Contains(a, item) {
+			a = append(a, item)
+		}
+	}
+	return a
+}
+
+// IsSubtask indica se un ticket è un sub-task: marcato come tale da Jira o di uno dei subtaskTypes
+func IsSubtask(issue jira.Issue, subtaskTypes []string) bool {
+	return issue.Fields.IssueType.Subtask || slices.ContainsFunc(subtaskTypes, func(t string) bool {
+		return strings.EqualFold(t, issue.Fields.IssueType.Name)
+	})
+}
+
+// isEpic indica se un ticket è un epic
+func isEpic(issue jira.Issue) bool {
+	return strings.EqualFold(issue.Fields.IssueType.Name, "epic")
+}
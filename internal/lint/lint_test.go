@@ -0,0 +1,94 @@
+package lint
+
+import (
+	"reflect"
+	"testing"
+
+	"jira-release-manager/internal/jira"
+)
+
+func issue(key, issueType, category string, versions ...string) jira.Issue {
+	i := jira.Issue{Key: key, Fields: jira.IssueFields{
+		IssueType: jira.IssueType{Name: issueType, Subtask: issueType == "Sub-task"},
+		Status:    jira.Status{Name: category, StatusCategory: jira.StatusCategory{Key: category}},
+		Labels:    []string{"api"},
+	}}
+	for _, name := range versions {
+		i.Fields.FixVersions = append(i.Fields.FixVersions, jira.Version{Name: name})
+	}
+	return i
+}
+
+func child(i jira.Issue, parent string) jira.Issue {
+	i.Fields.Parent = &jira.IssueRef{Key: parent}
+	return i
+}
+
+func TestRun(t *testing.T) {
+	issues := []jira.Issue{
+		issue("PROJ-1", "Epic", "indeterminate", "2.4.0"),
+		child(issue("PROJ-2", "Story", "done"), "PROJ-1"),
+		child(issue("PROJ-3", "Story", "done", "2.5.0"), "PROJ-1"),
+		child(issue("PROJ-4", "Sub-task", "new", "2.5.0"), "PROJ-5"),
+		child(issue("PROJ-6", "Sub-task", "new"), "PROJ-5"),
+		child(issue("PROJ-7", "Sub-task", "new", "2.4.0"), "PROJ-9"),
+		issue("PROJ-5", "Task", "new", "2.4.0", "2.3.0"),
+	}
+	issues[6].Fields.Labels = nil
+	parents := map[string]jira.Issue{"PROJ-9": issue("PROJ-9", "Task", "new", "2.6.0")}
+
+	problems := Run("2.4.0", issues, parents, nil)
+
+	type found struct {
+		check Check
+		key   string
+	}
+	var got []found
+	for _, p := range problems {
+		got = append(got, found{p.Check, p.Issue.Key})
+	}
+	want := []found{
+		{CheckSubtaskVersion, "PROJ-4"},
+		{CheckSubtaskVersion, "PROJ-7"},
+		{CheckEpicChildVersion, "PROJ-2"},
+		{CheckEpicChildVersion, "PROJ-3"},
+		{CheckMultipleVersions, "PROJ-5"},
+		{CheckMissingLabels, "PROJ-5"},
+		{CheckOpenEpic, "PROJ-1"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("problemi %v, attesi %v", got, want)
+	}
+
+	type fix struct {
+		key         string
+		add, remove []string
+	}
+	var gotFixes []fix
+	for _, f := range Fixes(problems) {
+		gotFixes = append(gotFixes, fix{f.Issue.Key, f.Add, f.Remove})
+	}
+	wantFixes := []fix{
+		{"PROJ-4", []string{"2.4.0", "2.3.0"}, []string{"2.5.0"}},
+		{"PROJ-7", []string{"2.6.0"}, []string{"2.4.0"}},
+		{"PROJ-2", []string{"2.4.0"}, nil},
+	}
+	if !reflect.DeepEqual(gotFixes, wantFixes) {
+		t.Errorf("correzioni %+v, attese %+v", gotFixes, wantFixes)
+	}
+}
+
+func TestRunSubtaskTypes(t *testing.T) {
+	// Un tipo personalizzato non marcato come sub-task da Jira (es. importato da un altro sistema)
+	subbug := child(issue("PROJ-2", "Sub-bug", "new", "2.5.0"), "PROJ-1")
+	issues := []jira.Issue{issue("PROJ-1", "Task", "new", "2.4.0"), subbug}
+
+	if problems := Run("2.4.0", issues, nil, nil); len(problems) != 0 {
+		t.Errorf("senza tipi personalizzati: problemi %+v, attesi nessuno", problems)
+	}
+
+	problems := Run("2.4.0", issues, nil, []string{"sub-bug"})
+	if len(problems) != 1 || problems[0].Check != CheckSubtaskVersion || problems[0].Issue.Key != "PROJ-2" {
+		t.Fatalf("problemi %+v, atteso subtask-version su PROJ-2", problems)
+	}
+}
@@ -2,6 +2,9 @@ package organizer
 
 import (
 	"fmt"
+	"os"
+	"sort"
+	"strconv"
 	"strings"
 
 	"jira-release-manager/internal/jira"
@@ -40,11 +43,11 @@ func NewReleaseHierarchy(issues []jira.Issue, debug bool) *ReleaseHierarchy {
 	}
 
 	if debug {
-		fmt.Println("\n🔍 DEBUG - Epic trovati:")
+		fmt.Fprintln(os.Stderr, "\n🔍 DEBUG - Epic trovati:")
 		for key := range h.Epics {
-			fmt.Printf("  - %s\n", key)
+			fmt.Fprintf(os.Stderr, "  - %s\n", key)
 		}
-		fmt.Println()
+		fmt.Fprintln(os.Stderr)
 	}
 
 	// Seconda passata: organizza story/task sotto epic o come standalone
@@ -65,7 +68,7 @@ func NewReleaseHierarchy(issues []jira.Issue, debug bool) *ReleaseHierarchy {
 		if issue.Fields.Epic != nil && issue.Fields.Epic.Key != "" {
 			epicKey = issue.Fields.Epic.Key
 			if debug {
-				fmt.Printf("🔍 DEBUG - %s ha Epic via campo 'epic': %s\n", issue.Key, epicKey)
+				fmt.Fprintf(os.Stderr, "🔍 DEBUG - %s ha Epic via campo 'epic': %s\n", issue.Key, epicKey)
 			}
 		}
 
@@ -76,7 +79,7 @@ func NewReleaseHierarchy(issues []jira.Issue, debug bool) *ReleaseHierarchy {
 			if _, isEpic := h.Epics[parentKey]; isEpic {
 				epicKey = parentKey
 				if debug {
-					fmt.Printf("🔍 DEBUG - %s ha Epic via campo 'parent': %s\n", issue.Key, epicKey)
+					fmt.Fprintf(os.Stderr, "🔍 DEBUG - %s ha Epic via campo 'parent': %s\n", issue.Key, epicKey)
 				}
 			}
 		}
@@ -86,29 +89,132 @@ func NewReleaseHierarchy(issues []jira.Issue, debug bool) *ReleaseHierarchy {
 			if _, epicExists := h.Epics[epicKey]; epicExists {
 				h.EpicChildren[epicKey] = append(h.EpicChildren[epicKey], issue)
 				if debug {
-					fmt.Printf("🔍 DEBUG - %s aggiunto sotto epic %s\n", issue.Key, epicKey)
+					fmt.Fprintf(os.Stderr, "🔍 DEBUG - %s aggiunto sotto epic %s\n", issue.Key, epicKey)
 				}
 				continue
 			} else if debug {
-				fmt.Printf("🔍 DEBUG - %s ha epic %s ma non è nella release\n", issue.Key, epicKey)
+				fmt.Fprintf(os.Stderr, "🔍 DEBUG - %s ha epic %s ma non è nella release\n", issue.Key, epicKey)
 			}
 		}
 
 		// Non ha epic o epic non in release: standalone
 		h.StandaloneIssues[issue.Fields.IssueType.Name] = append(h.StandaloneIssues[issue.Fields.IssueType.Name], issue)
 		if debug {
-			fmt.Printf("🔍 DEBUG - %s aggiunto come standalone\n", issue.Key)
+			fmt.Fprintf(os.Stderr, "🔍 DEBUG - %s aggiunto come standalone\n", issue.Key)
 		}
 	}
 
 	if debug {
-		fmt.Println("\n🔍 DEBUG - Riepilogo:")
-		fmt.Printf("  Epic: %d\n", len(h.Epics))
+		fmt.Fprintln(os.Stderr, "\n🔍 DEBUG - Riepilogo:")
+		fmt.Fprintf(os.Stderr, "  Epic: %d\n", len(h.Epics))
 		for epicKey, children := range h.EpicChildren {
-			fmt.Printf("  Epic %s ha %d figli\n", epicKey, len(children))
+			fmt.Fprintf(os.Stderr, "  Epic %s ha %d figli\n", epicKey, len(children))
 		}
-		fmt.Println()
+		fmt.Fprintln(os.Stderr)
 	}
 
 	return h
 }
+
+// SortedEpics restituisce gli epic della release ordinati per chiave.
+func (h *ReleaseHierarchy) SortedEpics() []jira.Issue {
+	keys := make([]string, 0, len(h.Epics))
+	for key := range h.Epics {
+		keys = append(keys, key)
+	}
+	SortIssueKeys(keys)
+
+	epics := make([]jira.Issue, 0, len(keys))
+	for _, key := range keys {
+		epics = append(epics, h.Epics[key])
+	}
+	return epics
+}
+
+// IssueTypes restituisce i tipi delle issue standalone, con quelli di preferredOrder
+// in testa (nell'ordine dato) e i restanti in ordine alfabetico.
+func (h *ReleaseHierarchy) IssueTypes(preferredOrder ...string) []string {
+	var types []string
+	seen := make(map[string]bool)
+	for _, issueType := range preferredOrder {
+		if len(h.StandaloneIssues[issueType]) > 0 && !seen[issueType] {
+			types = append(types, issueType)
+			seen[issueType] = true
+		}
+	}
+
+	var others []string
+	for issueType, issues := range h.StandaloneIssues {
+		if len(issues) > 0 && !seen[issueType] {
+			others = append(others, issueType)
+		}
+	}
+	sort.Strings(others)
+
+	return append(types, others...)
+}
+
+// OrphanSubtasks restituisce i sub-task il cui genitore non fa parte della release
+// (perché non ha la fixVersion oppure è completato), ordinati per genitore.
+func (h *ReleaseHierarchy) OrphanSubtasks() []jira.Issue {
+	var parentKeys []string
+	for parentKey := range h.SubtaskMap {
+		if !h.Contains(parentKey) {
+			parentKeys = append(parentKeys, parentKey)
+		}
+	}
+	SortIssueKeys(parentKeys)
+
+	var orphans []jira.Issue
+	for _, parentKey := range parentKeys {
+		orphans = append(orphans, h.SubtaskMap[parentKey]...)
+	}
+	return orphans
+}
+
+// Contains verifica se una issue (non sub-task) fa parte della gerarchia.
+func (h *ReleaseHierarchy) Contains(key string) bool {
+	if _, ok := h.Epics[key]; ok {
+		return true
+	}
+	for _, children := range h.EpicChildren {
+		for _, child := range children {
+			if child.Key == key {
+				return true
+			}
+		}
+	}
+	for _, issues := range h.StandaloneIssues {
+		for _, issue := range issues {
+			if issue.Key == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SortIssueKeys ordina le chiavi Jira per progetto e poi per numero (PROJ-9 prima di PROJ-10).
+func SortIssueKeys(keys []string) {
+	sort.Slice(keys, func(i, j int) bool {
+		pi, ni := splitIssueKey(keys[i])
+		pj, nj := splitIssueKey(keys[j])
+		if pi != pj {
+			return pi < pj
+		}
+		return ni < nj
+	})
+}
+
+// splitIssueKey separa una chiave Jira nel prefisso di progetto e nel numero.
+func splitIssueKey(key string) (string, int) {
+	idx := strings.LastIndex(key, "-")
+	if idx < 0 {
+		return key, 0
+	}
+	n, err := strconv.Atoi(key[idx+1:])
+	if err != nil {
+		return key, 0
+	}
+	return key[:idx], n
+}
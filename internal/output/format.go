@@ -0,0 +1,61 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Format rappresenta il formato di output dei comandi
+type Format string
+
+const (
+	// FormatText è l'output leggibile predefinito (alberi, riquadri)
+	FormatText Format = "text"
+	// FormatTable è un alias di FormatText, naturale per i comandi tabellari;
+	// ParseFormat lo normalizza in FormatText
+	FormatTable Format = "table"
+	// FormatJSON serializza i dati in JSON indentato
+	FormatJSON Format = "json"
+	// FormatYAML serializza i dati in YAML
+	FormatYAML Format = "yaml"
+)
+
+// ParseFormat valida e normalizza il valore del flag --output-format
+func ParseFormat(value string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(value))); f {
+	case "", FormatTable:
+		return FormatText, nil
+	case FormatText, FormatJSON, FormatYAML:
+		return f, nil
+	default:
+		return "", fmt.Errorf("formato di output non supportato: %q (valori ammessi: json, yaml, table, text)", value)
+	}
+}
+
+// IsStructured indica se il formato è destinato a essere letto da script
+func (f Format) IsStructured() bool {
+	return f == FormatJSON || f == FormatYAML
+}
+
+// Write serializza v nel formato strutturato richiesto
+func Write(w io.Writer, f Format, v interface{}) error {
+	switch f {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case FormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		return fmt.Errorf("il formato %q non è serializzabile", f)
+	}
+}
@@ -0,0 +1,46 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := map[string]Format{
+		"":        FormatText,
+		"text":    FormatText,
+		"table":   FormatText,
+		" TABLE ": FormatText,
+		"json":    FormatJSON,
+		"YAML":    FormatYAML,
+	}
+	for value, want := range tests {
+		got, err := ParseFormat(value)
+		if err != nil {
+			t.Errorf("ParseFormat(%q): %v", value, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseFormat(%q) = %q, atteso %q", value, got, want)
+		}
+	}
+
+	if _, err := ParseFormat("csv"); err == nil {
+		t.Error("atteso errore per un formato non supportato")
+	}
+}
+
+func TestIsStructured(t *testing.T) {
+	for format, want := range map[Format]bool{FormatText: false, FormatTable: false, FormatJSON: true, FormatYAML: true} {
+		if got := format.IsStructured(); got != want {
+			t.Errorf("%s.IsStructured() = %v, atteso %v", format, got, want)
+		}
+	}
+}
+
+func TestWriteRejectsText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatText, Version{Name: "2.4.0"}); err == nil {
+		t.Error("atteso errore serializzando in formato testuale")
+	}
+}
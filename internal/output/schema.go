@@ -0,0 +1,370 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+
+	"jira-release-manager/internal/jira"
+	"jira-release-manager/internal/lint"
+	"jira-release-manager/internal/organizer"
+	"jira-release-manager/internal/readiness"
+)
+
+// Lo schema seguente è il contratto stabile dell'output JSON/YAML: i campi
+// possono essere aggiunti, ma non rinominati o rimossi.
+
+// Version è la rappresentazione serializzata di una versione Jira
+type Version struct {
+	ID          string `json:"id" yaml:"id"`
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Released    bool   `json:"released" yaml:"released"`
+	Archived    bool   `json:"archived" yaml:"archived"`
+	ReleaseDate string `json:"releaseDate,omitempty" yaml:"releaseDate,omitempty"`
+	StartDate   string `json:"startDate,omitempty" yaml:"startDate,omitempty"`
+}
+
+// Issue è la rappresentazione serializzata di un ticket
+type Issue struct {
+	Key            string   `json:"key" yaml:"key"`
+	Summary        string   `json:"summary" yaml:"summary"`
+	Type           string   `json:"type" yaml:"type"`
+	Status         string   `json:"status" yaml:"status"`
+	StatusCategory string   `json:"statusCategory" yaml:"statusCategory"`
+	Priority       string   `json:"priority,omitempty" yaml:"priority,omitempty"`
+	Assignee       string   `json:"assignee,omitempty" yaml:"assignee,omitempty"`
+	Labels         []string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Parent         string   `json:"parent,omitempty" yaml:"parent,omitempty"`
+	FixVersions    []string `json:"fixVersions,omitempty" yaml:"fixVersions,omitempty"`
+	URL            string   `json:"url" yaml:"url"`
+	Subtasks       []Issue  `json:"subtasks,omitempty" yaml:"subtasks,omitempty"`
+}
+
+// Epic è un epic della release con le issue figlie
+type Epic struct {
+	Issue    `yaml:",inline"`
+	Children []Issue `json:"children" yaml:"children"`
+}
+
+// IssueGroup raggruppa le issue standalone per tipo
+type IssueGroup struct {
+	Type   string  `json:"type" yaml:"type"`
+	Issues []Issue `json:"issues" yaml:"issues"`
+}
+
+// Totals riassume i conteggi della release
+type Totals struct {
+	Epics        int `json:"epics" yaml:"epics"`
+	EpicChildren int `json:"epicChildren" yaml:"epicChildren"`
+	Standalone   int `json:"standalone" yaml:"standalone"`
+	Subtasks     int `json:"subtasks" yaml:"subtasks"`
+}
+
+// Release è la rappresentazione serializzata di una ReleaseHierarchy
+type Release struct {
+	Version        Version      `json:"version" yaml:"version"`
+	Epics          []Epic       `json:"epics" yaml:"epics"`
+	IssueTypes     []IssueGroup `json:"issueTypes" yaml:"issueTypes"`
+	OrphanSubtasks []Issue      `json:"orphanSubtasks" yaml:"orphanSubtasks"`
+	Totals         Totals       `json:"totals" yaml:"totals"`
+}
+
+// ReleaseRange è il changelog di un intervallo di versioni: una release per versione,
+// oppure una sola release con le modifiche unite
+type ReleaseRange struct {
+	From     Version   `json:"from" yaml:"from"`
+	To       Version   `json:"to" yaml:"to"`
+	Merged   bool      `json:"merged" yaml:"merged"`
+	Releases []Release `json:"releases" yaml:"releases"`
+}
+
+// Repository raggruppa le issue che condividono un'etichetta
+type Repository struct {
+	Label  string  `json:"label" yaml:"label"`
+	Issues []Issue `json:"issues" yaml:"issues"`
+}
+
+// ImpactedRepos è la rappresentazione serializzata dell'impatto sui repository
+type ImpactedRepos struct {
+	Version      Version      `json:"version" yaml:"version"`
+	Repositories []Repository `json:"repositories" yaml:"repositories"`
+}
+
+// GitIssue è un ticket citato nei commit del repository
+type GitIssue struct {
+	Issue   `yaml:",inline"`
+	Commits []string `json:"commits" yaml:"commits"`
+	Error   string   `json:"error,omitempty" yaml:"error,omitempty"` // Se il ticket non è stato recuperato da Jira
+}
+
+// GitVerification è il confronto tra i ticket di una versione e i commit di un repository
+type GitVerification struct {
+	Version        Version    `json:"version" yaml:"version"`
+	Repository     string     `json:"repository" yaml:"repository"`
+	From           string     `json:"from" yaml:"from"`
+	To             string     `json:"to" yaml:"to"`
+	InJiraAndGit   []GitIssue `json:"inJiraAndGit" yaml:"inJiraAndGit"`
+	MissingFromGit []Issue    `json:"missingFromGit" yaml:"missingFromGit"`
+	NotInVersion   []GitIssue `json:"notInVersion" yaml:"notInVersion"`
+}
+
+// ReadinessViolation è un ticket che non rispetta una regola di readiness
+type ReadinessViolation struct {
+	Issue  `yaml:",inline"`
+	Detail string `json:"detail" yaml:"detail"`
+}
+
+// ReadinessRule è l'esito di una regola di readiness
+type ReadinessRule struct {
+	Rule        string               `json:"rule" yaml:"rule"`
+	Description string               `json:"description" yaml:"description"`
+	Level       string               `json:"level" yaml:"level"`     // fail, warn, off
+	Outcome     string               `json:"outcome" yaml:"outcome"` // pass, warn, fail, skip
+	Violations  []ReadinessViolation `json:"violations" yaml:"violations"`
+}
+
+// Readiness è l'esito della verifica di readiness di una versione
+type Readiness struct {
+	Version Version         `json:"version" yaml:"version"`
+	Issues  int             `json:"issues" yaml:"issues"`
+	Outcome string          `json:"outcome" yaml:"outcome"` // pass, warn, fail
+	Rules   []ReadinessRule `json:"rules" yaml:"rules"`
+}
+
+// LintFix è la correzione proposta per le fixVersion di un ticket
+type LintFix struct {
+	Add    []string `json:"add,omitempty" yaml:"add,omitempty"`
+	Remove []string `json:"remove,omitempty" yaml:"remove,omitempty"`
+}
+
+// LintProblem è un problema strutturale trovato su un ticket
+type LintProblem struct {
+	Check  string `json:"check" yaml:"check"`
+	Issue  `yaml:",inline"`
+	Detail string   `json:"detail" yaml:"detail"`
+	Fix    *LintFix `json:"fix,omitempty" yaml:"fix,omitempty"`
+}
+
+// Lint è l'esito dei controlli strutturali sui ticket di una versione
+type Lint struct {
+	Version  Version       `json:"version" yaml:"version"`
+	Issues   int           `json:"issues" yaml:"issues"`
+	Problems []LintProblem `json:"problems" yaml:"problems"`
+}
+
+// FixVersionResult è l'esito della modifica delle fixVersion di un ticket
+type FixVersionResult struct {
+	Key     string   `json:"key" yaml:"key"`
+	Summary string   `json:"summary" yaml:"summary"`
+	URL     string   `json:"url" yaml:"url"`
+	Added   []string `json:"added,omitempty" yaml:"added,omitempty"`
+	Removed []string `json:"removed,omitempty" yaml:"removed,omitempty"`
+	Outcome string   `json:"outcome" yaml:"outcome"` // updated, unchanged, planned, failed
+	Error   string   `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// TransitionResult è l'esito della transizione di un ticket
+type TransitionResult struct {
+	Key        string `json:"key" yaml:"key"`
+	Summary    string `json:"summary" yaml:"summary"`
+	URL        string `json:"url" yaml:"url"`
+	Status     string `json:"status" yaml:"status"`                             // Stato prima della transizione
+	Transition string `json:"transition,omitempty" yaml:"transition,omitempty"` // Nome della transizione applicata
+	Outcome    string `json:"outcome" yaml:"outcome"`                           // transitioned, planned, already, skipped, unavailable, failed
+	Detail     string `json:"detail,omitempty" yaml:"detail,omitempty"`
+}
+
+// CommentResult è l'esito della pubblicazione del commento di rilascio su un ticket
+type CommentResult struct {
+	Key     string `json:"key" yaml:"key"`
+	Summary string `json:"summary" yaml:"summary"`
+	URL     string `json:"url" yaml:"url"`
+	Comment string `json:"comment" yaml:"comment"`
+	Outcome string `json:"outcome" yaml:"outcome"` // posted, planned, exists, failed
+	Error   string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// NewVersion converte una versione Jira nello schema di output
+func NewVersion(v jira.Version) Version {
+	return Version{
+		ID:          v.ID,
+		Name:        v.Name,
+		Description: v.Description,
+		Released:    v.Released,
+		Archived:    v.Archived,
+		ReleaseDate: v.ReleaseDate,
+		StartDate:   v.StartDate,
+	}
+}
+
+// NewVersions converte una lista di versioni preservandone l'ordine
+func NewVersions(versions []jira.Version) []Version {
+	out := make([]Version, 0, len(versions))
+	for _, v := range versions {
+		out = append(out, NewVersion(v))
+	}
+	return out
+}
+
+// NewIssue converte un ticket Jira nello schema di output
+func NewIssue(issue jira.Issue, baseURL string) Issue {
+	out := Issue{
+		Key:            issue.Key,
+		Summary:        issue.Fields.Summary,
+		Type:           issue.Fields.IssueType.Name,
+		Status:         issue.Fields.Status.Name,
+		StatusCategory: issue.Fields.Status.StatusCategory.Key,
+		Labels:         issue.Fields.Labels,
+		URL:            fmt.Sprintf("%s/browse/%s", baseURL, issue.Key),
+	}
+	if issue.Fields.Priority != nil {
+		out.Priority = issue.Fields.Priority.Name
+	}
+	if issue.Fields.Assignee != nil {
+		out.Assignee = issue.Fields.Assignee.DisplayName
+	}
+	if issue.Fields.Parent != nil {
+		out.Parent = issue.Fields.Parent.Key
+	}
+	for _, v := range issue.Fields.FixVersions {
+		out.FixVersions = append(out.FixVersions, v.Name)
+	}
+	return out
+}
+
+// NewRelease converte una ReleaseHierarchy nello schema di output, con ordinamento stabile
+func NewRelease(version *jira.Version, hierarchy *organizer.ReleaseHierarchy, baseURL string) Release {
+	release := Release{
+		Version:        NewVersion(*version),
+		Epics:          []Epic{},
+		IssueTypes:     []IssueGroup{},
+		OrphanSubtasks: []Issue{},
+	}
+
+	withSubtasks := func(issue jira.Issue) Issue {
+		out := NewIssue(issue, baseURL)
+		for _, subtask := range hierarchy.SubtaskMap[issue.Key] {
+			out.Subtasks = append(out.Subtasks, NewIssue(subtask, baseURL))
+			release.Totals.Subtasks++
+		}
+		return out
+	}
+
+	for _, epicIssue := range hierarchy.SortedEpics() {
+		epic := Epic{Issue: withSubtasks(epicIssue), Children: []Issue{}}
+		for _, child := range hierarchy.EpicChildren[epicIssue.Key] {
+			epic.Children = append(epic.Children, withSubtasks(child))
+			release.Totals.EpicChildren++
+		}
+		release.Epics = append(release.Epics, epic)
+	}
+	release.Totals.Epics = len(release.Epics)
+
+	for _, issueType := range hierarchy.IssueTypes() {
+		group := IssueGroup{Type: issueType, Issues: []Issue{}}
+		for _, issue := range hierarchy.StandaloneIssues[issueType] {
+			group.Issues = append(group.Issues, withSubtasks(issue))
+			release.Totals.Standalone++
+		}
+		release.IssueTypes = append(release.IssueTypes, group)
+	}
+
+	for _, subtask := range hierarchy.OrphanSubtasks() {
+		release.OrphanSubtasks = append(release.OrphanSubtasks, NewIssue(subtask, baseURL))
+		release.Totals.Subtasks++
+	}
+
+	return release
+}
+
+// NewImpactedRepos converte la mappa etichetta -> issue nello schema di output
+func NewImpactedRepos(version *jira.Version, labelsToIssues map[string][]jira.Issue, baseURL string) ImpactedRepos {
+	out := ImpactedRepos{
+		Version:      NewVersion(*version),
+		Repositories: []Repository{},
+	}
+
+	labels := make([]string, 0, len(labelsToIssues))
+	for label := range labelsToIssues {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	for _, label := range labels {
+		repo := Repository{Label: label, Issues: []Issue{}}
+		for _, issue := range labelsToIssues[label] {
+			repo.Issues = append(repo.Issues, NewIssue(issue, baseURL))
+		}
+		out.Repositories = append(out.Repositories, repo)
+	}
+
+	return out
+}
+
+// NewReadiness converte l'esito della verifica di readiness nello schema di output
+func NewReadiness(version *jira.Version, report readiness.Report, baseURL string) Readiness {
+	out := Readiness{
+		Version: NewVersion(*version),
+		Issues:  report.Issues,
+		Outcome: string(report.Outcome),
+		Rules:   []ReadinessRule{},
+	}
+
+	for _, result := range report.Results {
+		rule := ReadinessRule{
+			Rule:        result.Rule,
+			Description: result.Description,
+			Level:       string(result.Level),
+			Outcome:     string(result.Outcome),
+			Violations:  []ReadinessViolation{},
+		}
+		for _, violation := range result.Violations {
+			rule.Violations = append(rule.Violations, ReadinessViolation{
+				Issue:  NewIssue(violation.Issue, baseURL),
+				Detail: violation.Detail,
+			})
+		}
+		out.Rules = append(out.Rules, rule)
+	}
+
+	return out
+}
+
+// NewLint converte i problemi trovati da lint nello schema di output
+func NewLint(version *jira.Version, issues int, problems []lint.Problem, baseURL string) Lint {
+	out := Lint{
+		Version:  NewVersion(*version),
+		Issues:   issues,
+		Problems: []LintProblem{},
+	}
+
+	for _, problem := range problems {
+		item := LintProblem{
+			Check:  string(problem.Check),
+			Issue:  NewIssue(problem.Issue, baseURL),
+			Detail: problem.Detail,
+		}
+		if problem.Fix != nil {
+			item.Fix = &LintFix{Add: problem.Fix.Add, Remove: problem.Fix.Remove}
+		}
+		out.Problems = append(out.Problems, item)
+	}
+
+	return out
+}
+
+// NewFixVersionResult converte l'esito della modifica delle fixVersion di un ticket nello schema di output
+func NewFixVersionResult(update jira.FixVersionUpdate, outcome string, err error, baseURL string) FixVersionResult {
+	out := FixVersionResult{
+		Key:     update.Issue.Key,
+		Summary: update.Issue.Fields.Summary,
+		URL:     fmt.Sprintf("%s/browse/%s", baseURL, update.Issue.Key),
+		Added:   update.Add,
+		Removed: update.Remove,
+		Outcome: outcome,
+	}
+	if err != nil {
+		out.Error = err.Error()
+	}
+	return out
+}
@@ -0,0 +1,125 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"jira-release-manager/internal/jira"
+	"jira-release-manager/internal/lint"
+	"jira-release-manager/internal/organizer"
+	"jira-release-manager/internal/readiness"
+)
+
+// update rigenera i file golden: go test ./internal/output -update
+var update = flag.Bool("update", false, "rigenera i file golden in testdata")
+
+// newTestSchema crea un esempio dei documenti serializzati dai comandi, con tutti i campi valorizzati
+func newTestSchema() map[string]interface{} {
+	const baseURL = "https://jira.example.com"
+	version := &jira.Version{ID: "10042", Name: "2.4.0", Description: "Single sign-on", Released: true, ReleaseDate: "2026-10-12", StartDate: "2026-09-28"}
+
+	epic := jira.Issue{Key: "PROJ-1", Fields: jira.IssueFields{Summary: "Autenticazione", IssueType: jira.IssueType{Name: "Epic"},
+		Status: jira.Status{Name: "Done", StatusCategory: jira.StatusCategory{Key: "done"}}}}
+	story := jira.Issue{Key: "PROJ-2", Fields: jira.IssueFields{
+		Summary:     "Login con SSO",
+		IssueType:   jira.IssueType{Name: "Story"},
+		Status:      jira.Status{Name: "In Review", StatusCategory: jira.StatusCategory{Key: "indeterminate"}},
+		Priority:    &jira.Priority{Name: "High"},
+		Assignee:    &jira.User{DisplayName: "Mario Rossi"},
+		Labels:      []string{"backend"},
+		Parent:      &jira.IssueRef{Key: "PROJ-1"},
+		FixVersions: []jira.Version{{Name: "2.4.0"}, {Name: "2.5.0"}},
+	}}
+	subtask := jira.Issue{Key: "PROJ-3", Fields: jira.IssueFields{Summary: "Configurare l'IdP", IssueType: jira.IssueType{Name: "Sub-task", Subtask: true},
+		Parent: &jira.IssueRef{Key: "PROJ-2"}}}
+	bug := jira.Issue{Key: "PROJ-4", Fields: jira.IssueFields{Summary: "Crash all'avvio", IssueType: jira.IssueType{Name: "Bug"}}}
+	orphan := jira.Issue{Key: "PROJ-8", Fields: jira.IssueFields{Summary: "Documentazione", IssueType: jira.IssueType{Name: "Sub-task", Subtask: true},
+		Parent: &jira.IssueRef{Key: "PROJ-99"}}}
+
+	hierarchy := organizer.NewReleaseHierarchy([]jira.Issue{epic, story, subtask, bug, orphan}, false)
+	problems := []lint.Problem{{
+		Check:  lint.CheckMultipleVersions,
+		Issue:  story,
+		Detail: "fixVersion: 2.4.0, 2.5.0",
+		Fix:    &jira.FixVersionChange{Remove: []string{"2.5.0"}},
+	}}
+	fixUpdate := jira.FixVersionUpdate{Issue: story, FixVersionChange: jira.FixVersionChange{Add: []string{"2.4.1"}, Remove: []string{"2.5.0"}}}
+
+	return map[string]interface{}{
+		"release":    NewRelease(version, hierarchy, baseURL),
+		"impacted":   NewImpactedRepos(version, map[string][]jira.Issue{"backend": {story}}, baseURL),
+		"lint":       NewLint(version, 5, problems, baseURL),
+		"fixVersion": NewFixVersionResult(fixUpdate, "failed", errors.New("permesso negato"), baseURL),
+		"transition": TransitionResult{Key: "PROJ-2", Summary: "Login con SSO", URL: baseURL + "/browse/PROJ-2", Status: "In Review",
+			Transition: "Release", Outcome: "failed", Detail: "permesso negato"},
+		"comment": CommentResult{Key: "PROJ-2", Summary: "Login con SSO", URL: baseURL + "/browse/PROJ-2", Comment: "Shipped in v2.4.0",
+			Outcome: "failed", Error: "permesso negato"},
+		"git": GitVerification{Version: NewVersion(*version), Repository: "/src/app", From: "v2.3.0", To: "HEAD",
+			InJiraAndGit:   []GitIssue{{Issue: NewIssue(story, baseURL), Commits: []string{"abc1234"}}},
+			MissingFromGit: []Issue{NewIssue(bug, baseURL)},
+			NotInVersion:   []GitIssue{{Issue: Issue{Key: "PROJ-9"}, Commits: []string{"def5678"}, Error: "ticket non trovato"}}},
+		"readiness": NewReadiness(version, readiness.Report{Issues: 5, Outcome: readiness.OutcomeFail, Results: []readiness.RuleResult{{
+			Rule: readiness.RuleUnassigned, Description: "Ticket senza assegnatario", Level: readiness.LevelFail, Outcome: readiness.OutcomeFail,
+			Violations: []readiness.Violation{{Issue: bug, Detail: "nessun assegnatario"}}}}}, baseURL),
+	}
+}
+
+func TestSchemaGolden(t *testing.T) {
+	for _, format := range []Format{FormatJSON, FormatYAML} {
+		var buf bytes.Buffer
+		if err := Write(&buf, format, newTestSchema()); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+
+		golden := filepath.Join("testdata", "schema."+string(format))
+		if *update {
+			if err := os.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		want, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != string(want) {
+			t.Errorf("%s: output diverso da %s: lo schema è un contratto stabile, i campi si possono solo aggiungere (rigenera con -update se la modifica è voluta):\n%s",
+				format, golden, buf.String())
+		}
+	}
+}
+
+func TestSchemaTagsMatch(t *testing.T) {
+	types := []interface{}{
+		Version{}, Issue{}, Epic{}, IssueGroup{}, Totals{}, Release{}, ReleaseRange{}, Repository{}, ImpactedRepos{},
+		GitIssue{}, GitVerification{}, ReadinessViolation{}, ReadinessRule{}, Readiness{}, LintFix{}, LintProblem{}, Lint{},
+		FixVersionResult{}, TransitionResult{}, CommentResult{},
+	}
+
+	// JSON e YAML devono esporre gli stessi nomi di campo; gli struct incorporati sono appiattiti in entrambi
+	for _, v := range types {
+		typ := reflect.TypeOf(v)
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			jsonTag, yamlTag := field.Tag.Get("json"), field.Tag.Get("yaml")
+			if field.Anonymous {
+				if jsonTag != "" || yamlTag != ",inline" {
+					t.Errorf("%s.%s: struct incorporato con tag json %q e yaml %q, attesi \"\" e \",inline\"", typ.Name(), field.Name, jsonTag, yamlTag)
+				}
+				continue
+			}
+			if jsonTag == "" || jsonTag != yamlTag {
+				t.Errorf("%s.%s: tag json %q e yaml %q diversi", typ.Name(), field.Name, jsonTag, yamlTag)
+			}
+			if name, _, _ := strings.Cut(jsonTag, ","); name == "" || name == "-" {
+				t.Errorf("%s.%s: campo senza nome nello schema", typ.Name(), field.Name)
+			}
+		}
+	}
+}
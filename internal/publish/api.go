@@ -0,0 +1,116 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// APIError rappresenta una risposta non 2xx delle API di GitHub o GitLab
+type APIError struct {
+	StatusCode int
+	Method     string
+	Endpoint   string
+	Message    string
+}
+
+// Error restituisce una descrizione leggibile dell'errore
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("errore HTTP %d (%s %s)", e.StatusCode, e.Method, e.Endpoint)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// isNotFound indica se l'errore è una risposta 404
+func isNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// apiClient è un client REST minimale con intestazioni di autenticazione fisse
+type apiClient struct {
+	baseURL string
+	header  http.Header
+}
+
+// doJSON esegue una richiesta con body JSON (se non nil) e decodifica la risposta in v (se non nil)
+func (c *apiClient) doJSON(ctx context.Context, method, endpoint string, body, v interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("errore nella serializzazione JSON: %w", err)
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+endpoint, reqBody)
+	if err != nil {
+		return fmt.Errorf("errore nella creazione della richiesta: %w", err)
+	}
+	for name, values := range c.header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := defaultHTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("errore nella richiesta HTTP: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("errore nella lettura della risposta: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &APIError{StatusCode: resp.StatusCode, Method: method, Endpoint: endpoint, Message: errorMessage(data)}
+	}
+
+	if v == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("errore nel parsing JSON: %w", err)
+	}
+	return nil
+}
+
+// errorMessage estrae il messaggio di errore dal body; GitHub e GitLab usano entrambi il campo message
+// (su GitLab a volte un oggetto), altrimenti viene restituito il body grezzo
+func errorMessage(data []byte) string {
+	var payload struct {
+		Message interface{} `json:"message"`
+		Error   string      `json:"error"`
+	}
+	if err := json.Unmarshal(data, &payload); err == nil {
+		switch message := payload.Message.(type) {
+		case string:
+			if message != "" {
+				return message
+			}
+		case nil:
+		default:
+			if encoded, err := json.Marshal(message); err == nil {
+				return string(encoded)
+			}
+		}
+		if payload.Error != "" {
+			return payload.Error
+		}
+	}
+
+	body := strings.TrimSpace(string(data))
+	if len(body) > 200 {
+		body = body[:197] + "..."
+	}
+	return body
+}
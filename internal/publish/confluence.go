@@ -0,0 +1,154 @@
+package publish
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+
+	"jira-release-manager/internal/jira"
+)
+
+// ConfluencePage è una pagina Confluence restituita dalle API di contenuto
+type ConfluencePage struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Version struct {
+		Number int `json:"number"`
+	} `json:"version"`
+	Links struct {
+		Base  string `json:"base"`
+		WebUI string `json:"webui"`
+	} `json:"_links"`
+}
+
+// URL restituisce il link alla pagina nell'interfaccia web di Confluence
+func (p *ConfluencePage) URL() string {
+	return p.Links.Base + p.Links.WebUI
+}
+
+// ConfluencePageInput descrive la pagina da creare o aggiornare
+type ConfluencePageInput struct {
+	SpaceKey string
+	ParentID string // Pagina genitore; se vuoto la pagina viene creata nella radice dello spazio
+	Title    string
+	Body     string // Contenuto in storage format
+}
+
+// ConfluenceStorage converte una release nello storage format (XHTML) di Confluence.
+// I ticket sono mostrati con la macro Jira, che ne riporta sommario e stato aggiornati;
+// jiraServerID identifica il collegamento applicativo verso Jira e può essere vuoto
+// se Confluence ne ha uno solo.
+func ConfluenceStorage(release Release, jiraServerID string) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "<p><strong>Data di rilascio</strong>: %s</p>\n", html.EscapeString(release.ReleaseDate))
+	if release.Version.Description != "" {
+		fmt.Fprintf(&sb, "<p><strong>Descrizione</strong>: %s</p>\n", html.EscapeString(release.Version.Description))
+	}
+	fmt.Fprintf(&sb, "<p><a href=\"%s\">Apri la release in Jira</a> · <a href=\"%s\">Tutti i ticket</a></p>\n",
+		html.EscapeString(release.VersionURL()), html.EscapeString(release.SearchURL()))
+
+	for _, section := range release.Sections() {
+		fmt.Fprintf(&sb, "<h2>%s</h2>\n", html.EscapeString(section.Title))
+		if section.Epic != nil {
+			fmt.Fprintf(&sb, "<p>%s</p>\n", jiraMacro(section.Epic.Key, jiraServerID))
+		}
+
+		if len(section.Issues) > 0 {
+			sb.WriteString("<ul>\n")
+			for _, issue := range section.Issues {
+				sb.WriteString("<li>" + jiraMacro(issue.Key, jiraServerID))
+				if release.IncludeSubtasks && !issue.Fields.IssueType.Subtask {
+					confluenceList(&sb, release.Hierarchy.SubtaskMap[issue.Key], jiraServerID)
+				}
+				sb.WriteString("</li>\n")
+			}
+			sb.WriteString("</ul>\n")
+		}
+		if section.Epic != nil && release.IncludeSubtasks {
+			confluenceList(&sb, release.Hierarchy.SubtaskMap[section.Epic.Key], jiraServerID)
+		}
+	}
+
+	return sb.String()
+}
+
+// confluenceList scrive una lista di ticket, se non vuota
+func confluenceList(sb *strings.Builder, issues []jira.Issue, jiraServerID string) {
+	if len(issues) == 0 {
+		return
+	}
+	sb.WriteString("<ul>")
+	for _, issue := range issues {
+		sb.WriteString("<li>" + jiraMacro(issue.Key, jiraServerID) + "</li>")
+	}
+	sb.WriteString("</ul>")
+}
+
+// jiraMacro restituisce la macro Jira per un singolo ticket
+func jiraMacro(key, jiraServerID string) string {
+	macro := `<ac:structured-macro ac:name="jira" ac:schema-version="1">`
+	macro += fmt.Sprintf(`<ac:parameter ac:name="key">%s</ac:parameter>`, html.EscapeString(key))
+	if jiraServerID != "" {
+		macro += fmt.Sprintf(`<ac:parameter ac:name="serverId">%s</ac:parameter>`, html.EscapeString(jiraServerID))
+	}
+	return macro + `</ac:structured-macro>`
+}
+
+// FindConfluencePage cerca una pagina per titolo in uno spazio; restituisce nil se non esiste
+func FindConfluencePage(ctx context.Context, client *jira.Client, spaceKey, title string) (*ConfluencePage, error) {
+	query := url.Values{}
+	query.Set("spaceKey", spaceKey)
+	query.Set("title", title)
+	query.Set("type", "page")
+	query.Set("expand", "version")
+
+	var result struct {
+		Results []ConfluencePage `json:"results"`
+	}
+	if err := client.GetJSON(ctx, "/rest/api/content?"+query.Encode(), &result); err != nil {
+		return nil, fmt.Errorf("impossibile cercare la pagina '%s' nello spazio %s: %w", title, spaceKey, err)
+	}
+	if len(result.Results) == 0 {
+		return nil, nil
+	}
+	return &result.Results[0], nil
+}
+
+// PublishConfluencePage crea la pagina o, se nello spazio ne esiste già una con lo stesso titolo,
+// ne aggiorna il contenuto creando una nuova versione. Restituisce la pagina e se è stata creata.
+func PublishConfluencePage(ctx context.Context, client *jira.Client, input ConfluencePageInput) (*ConfluencePage, bool, error) {
+	existing, err := FindConfluencePage(ctx, client, input.SpaceKey, input.Title)
+	if err != nil {
+		return nil, false, err
+	}
+
+	payload := map[string]interface{}{
+		"type":  "page",
+		"title": input.Title,
+		"space": map[string]string{"key": input.SpaceKey},
+		"body": map[string]interface{}{
+			"storage": map[string]string{"value": input.Body, "representation": "storage"},
+		},
+	}
+	if input.ParentID != "" {
+		payload["ancestors"] = []map[string]string{{"id": input.ParentID}}
+	}
+
+	var page ConfluencePage
+	if existing == nil {
+		if err := client.PostJSON(ctx, "/rest/api/content", payload, &page); err != nil {
+			return nil, false, fmt.Errorf("impossibile creare la pagina '%s': %w", input.Title, err)
+		}
+		return &page, true, nil
+	}
+
+	payload["id"] = existing.ID
+	payload["version"] = map[string]int{"number": existing.Version.Number + 1}
+	if err := client.PutJSON(ctx, "/rest/api/content/"+existing.ID, payload, &page); err != nil {
+		return nil, false, fmt.Errorf("impossibile aggiornare la pagina '%s': %w", input.Title, err)
+	}
+	return &page, false, nil
+}
@@ -0,0 +1,114 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"jira-release-manager/internal/jira"
+)
+
+func TestConfluenceStorage(t *testing.T) {
+	release := newTestRelease()
+	release.Version.Description = `Login <sicuro> & "veloce"`
+
+	body := ConfluenceStorage(release, "srv-1")
+
+	for _, key := range []string{"PROJ-1", "PROJ-2", "PROJ-3"} {
+		macro := `<ac:structured-macro ac:name="jira" ac:schema-version="1"><ac:parameter ac:name="key">` + key +
+			`</ac:parameter><ac:parameter ac:name="serverId">srv-1</ac:parameter></ac:structured-macro>`
+		if !strings.Contains(body, macro) {
+			t.Errorf("macro Jira di %s mancante:\n%s", key, body)
+		}
+	}
+	if !strings.Contains(body, "Login &lt;sicuro&gt; &amp; &#34;veloce&#34;") {
+		t.Errorf("descrizione non sottoposta a escaping:\n%s", body)
+	}
+	// Il sommario è mostrato dalla macro, non copiato nella pagina
+	if strings.Contains(body, "Crash all'avvio") {
+		t.Errorf("sommario copiato nella pagina:\n%s", body)
+	}
+
+	if body := ConfluenceStorage(release, ""); strings.Contains(body, "serverId") {
+		t.Errorf("serverId presente senza collegamento configurato:\n%s", body)
+	}
+}
+
+// newConfluenceServer simula le API di contenuto di Confluence con una pagina esistente opzionale
+// e registra metodo, percorso e payload della pubblicazione
+func newConfluenceServer(t *testing.T, existing string, method, path *string, payload *map[string]interface{}) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			if r.URL.Path != "/rest/api/content" || r.URL.Query().Get("spaceKey") != "REL" || r.URL.Query().Get("title") != "Changelog - Versione 2.4.0" {
+				t.Errorf("ricerca inattesa: %s", r.URL.RequestURI())
+			}
+			_, _ = w.Write([]byte(`{"results":[` + existing + `]}`))
+			return
+		}
+
+		*method, *path = r.Method, r.URL.Path
+		if err := json.NewDecoder(r.Body).Decode(payload); err != nil {
+			t.Errorf("payload non valido: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"id":"98765","title":"Changelog - Versione 2.4.0","version":{"number":4},"_links":{"base":"https://example.atlassian.net/wiki","webui":"/pages/98765"}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPublishConfluencePageCreates(t *testing.T) {
+	var method, path string
+	var payload map[string]interface{}
+	server := newConfluenceServer(t, "", &method, &path, &payload)
+	client := &jira.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+
+	input := ConfluencePageInput{SpaceKey: "REL", ParentID: "123456", Title: "Changelog - Versione 2.4.0", Body: "<p>ciao</p>"}
+	page, created, err := PublishConfluencePage(context.Background(), client, input)
+	if err != nil || !created {
+		t.Fatalf("created=%v err=%v", created, err)
+	}
+	if page.URL() != "https://example.atlassian.net/wiki/pages/98765" {
+		t.Errorf("URL della pagina = %q", page.URL())
+	}
+	if method != http.MethodPost || path != "/rest/api/content" {
+		t.Errorf("richiesta %s %s, attesa POST /rest/api/content", method, path)
+	}
+
+	want := map[string]interface{}{
+		"type":      "page",
+		"title":     "Changelog - Versione 2.4.0",
+		"space":     map[string]interface{}{"key": "REL"},
+		"body":      map[string]interface{}{"storage": map[string]interface{}{"value": "<p>ciao</p>", "representation": "storage"}},
+		"ancestors": []interface{}{map[string]interface{}{"id": "123456"}},
+	}
+	if !reflect.DeepEqual(payload, want) {
+		t.Errorf("payload %v, atteso %v", payload, want)
+	}
+}
+
+func TestPublishConfluencePageUpdates(t *testing.T) {
+	var method, path string
+	var payload map[string]interface{}
+	server := newConfluenceServer(t, `{"id":"98765","title":"Changelog - Versione 2.4.0","version":{"number":3}}`, &method, &path, &payload)
+	client := &jira.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+
+	input := ConfluencePageInput{SpaceKey: "REL", Title: "Changelog - Versione 2.4.0", Body: "<p>ciao</p>"}
+	page, created, err := PublishConfluencePage(context.Background(), client, input)
+	if err != nil || created || page.Version.Number != 4 {
+		t.Fatalf("page=%+v created=%v err=%v", page, created, err)
+	}
+	if method != http.MethodPut || path != "/rest/api/content/98765" {
+		t.Errorf("richiesta %s %s, attesa PUT /rest/api/content/98765", method, path)
+	}
+	if payload["id"] != "98765" || !reflect.DeepEqual(payload["version"], map[string]interface{}{"number": float64(4)}) {
+		t.Errorf("id o versione non attesi: %v", payload)
+	}
+	if _, ok := payload["ancestors"]; ok {
+		t.Errorf("ancestors inviato senza --parent-id: %v", payload)
+	}
+}
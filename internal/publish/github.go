@@ -0,0 +1,125 @@
+package publish
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultGitHubAPIURL è l'indirizzo delle API di github.com; per GitHub Enterprise è https://<host>/api/v3
+const DefaultGitHubAPIURL = "https://api.github.com"
+
+// GitHubRelease è una release di un repository GitHub
+type GitHubRelease struct {
+	ID         int64  `json:"id"`
+	TagName    string `json:"tag_name"`
+	Name       string `json:"name"`
+	Body       string `json:"body"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+	HTMLURL    string `json:"html_url"`
+}
+
+// ReleaseInput descrive la release da creare o aggiornare su GitHub o GitLab
+type ReleaseInput struct {
+	Tag        string
+	Name       string
+	Body       string // Changelog in Markdown
+	Ref        string // Commit o branch da cui creare il tag, se non esiste ancora
+	Draft      bool   // Solo GitHub
+	Prerelease bool   // Solo GitHub
+}
+
+// GitHubClient crea e aggiorna le release di GitHub o GitHub Enterprise
+type GitHubClient struct {
+	api apiClient
+}
+
+// NewGitHubClient crea un client per le API di GitHub autenticato con un token
+func NewGitHubClient(baseURL, token string) *GitHubClient {
+	header := http.Header{}
+	header.Set("Accept", "application/vnd.github+json")
+	header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+	return &GitHubClient{api: apiClient{baseURL: strings.TrimSuffix(baseURL, "/"), header: header}}
+}
+
+// PublishRelease crea la release del tag nel repository (owner/nome) o, se esiste già, ne aggiorna
+// nome, descrizione e stato di bozza e prerelease. Restituisce la release e se è stata creata.
+func (c *GitHubClient) PublishRelease(ctx context.Context, repo string, input ReleaseInput) (*GitHubRelease, bool, error) {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return nil, false, fmt.Errorf("repository non valido '%s': usa il formato owner/nome", repo)
+	}
+	base := fmt.Sprintf("/repos/%s/%s/releases", url.PathEscape(owner), url.PathEscape(name))
+
+	existing, err := c.findRelease(ctx, base, input.Tag)
+	if err != nil {
+		return nil, false, fmt.Errorf("impossibile recuperare la release %s: %w", input.Tag, err)
+	}
+
+	var release GitHubRelease
+	if existing == nil {
+		payload := map[string]interface{}{
+			"tag_name":   input.Tag,
+			"name":       input.Name,
+			"body":       input.Body,
+			"draft":      input.Draft,
+			"prerelease": input.Prerelease,
+		}
+		if input.Ref != "" {
+			payload["target_commitish"] = input.Ref
+		}
+		if err := c.api.doJSON(ctx, http.MethodPost, base, payload, &release); err != nil {
+			return nil, false, fmt.Errorf("impossibile creare la release %s: %w", input.Tag, err)
+		}
+		return &release, true, nil
+	}
+
+	// draft e prerelease sono sempre inviati, così una bozza può essere pubblicata e una prerelease promossa
+	payload := map[string]interface{}{
+		"name":       input.Name,
+		"body":       input.Body,
+		"draft":      input.Draft,
+		"prerelease": input.Prerelease,
+	}
+	if err := c.api.doJSON(ctx, http.MethodPatch, fmt.Sprintf("%s/%d", base, existing.ID), payload, &release); err != nil {
+		return nil, false, fmt.Errorf("impossibile aggiornare la release %s: %w", input.Tag, err)
+	}
+	return &release, false, nil
+}
+
+// githubPageSize è il numero di release richieste per pagina nella ricerca delle bozze
+const githubPageSize = 100
+
+// findRelease cerca la release di un tag, restituendo nil se non esiste. L'endpoint /releases/tags/{tag}
+// non restituisce le bozze, che vengono quindi cercate nell'elenco completo delle release.
+func (c *GitHubClient) findRelease(ctx context.Context, base, tag string) (*GitHubRelease, error) {
+	var release GitHubRelease
+	err := c.api.doJSON(ctx, http.MethodGet, base+"/tags/"+url.PathEscape(tag), nil, &release)
+	if err == nil {
+		return &release, nil
+	}
+	if !isNotFound(err) {
+		return nil, err
+	}
+
+	for page := 1; ; page++ {
+		var releases []GitHubRelease
+		if err := c.api.doJSON(ctx, http.MethodGet, fmt.Sprintf("%s?per_page=%d&page=%d", base, githubPageSize, page), nil, &releases); err != nil {
+			return nil, err
+		}
+		for i := range releases {
+			if releases[i].TagName == tag {
+				return &releases[i], nil
+			}
+		}
+		if len(releases) < githubPageSize {
+			return nil, nil
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package publish
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultGitLabAPIURL è l'indirizzo delle API di gitlab.com; per un'istanza self-hosted è https://<host>/api/v4
+const DefaultGitLabAPIURL = "https://gitlab.com/api/v4"
+
+// GitLabRelease è una release di un progetto GitLab
+type GitLabRelease struct {
+	TagName     string `json:"tag_name"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Links       struct {
+		Self string `json:"self"`
+	} `json:"_links"`
+}
+
+// GitLabClient crea e aggiorna le release di GitLab
+type GitLabClient struct {
+	api apiClient
+}
+
+// NewGitLabClient crea un client per le API di GitLab autenticato con un token personale o di progetto
+func NewGitLabClient(baseURL, token string) *GitLabClient {
+	header := http.Header{}
+	if token != "" {
+		header.Set("PRIVATE-TOKEN", token)
+	}
+	return &GitLabClient{api: apiClient{baseURL: strings.TrimSuffix(baseURL, "/"), header: header}}
+}
+
+// PublishRelease crea la release del tag nel progetto (ID numerico o percorso gruppo/nome) o,
+// se esiste già, ne aggiorna nome e descrizione. Restituisce la release e se è stata creata.
+func (c *GitLabClient) PublishRelease(ctx context.Context, project string, input ReleaseInput) (*GitLabRelease, bool, error) {
+	base := fmt.Sprintf("/projects/%s/releases", url.PathEscape(project))
+	endpoint := base + "/" + url.PathEscape(input.Tag)
+
+	var existing GitLabRelease
+	err := c.api.doJSON(ctx, http.MethodGet, endpoint, nil, &existing)
+	if err != nil && !isNotFound(err) {
+		return nil, false, fmt.Errorf("impossibile recuperare la release %s: %w", input.Tag, err)
+	}
+
+	var release GitLabRelease
+	if err != nil {
+		payload := map[string]interface{}{
+			"tag_name":    input.Tag,
+			"name":        input.Name,
+			"description": input.Body,
+		}
+		if input.Ref != "" {
+			payload["ref"] = input.Ref
+		}
+		if err := c.api.doJSON(ctx, http.MethodPost, base, payload, &release); err != nil {
+			return nil, false, fmt.Errorf("impossibile creare la release %s: %w", input.Tag, err)
+		}
+		return &release, true, nil
+	}
+
+	payload := map[string]interface{}{
+		"name":        input.Name,
+		"description": input.Body,
+	}
+	if err := c.api.doJSON(ctx, http.MethodPut, endpoint, payload, &release); err != nil {
+		return nil, false, fmt.Errorf("impossibile aggiornare la release %s: %w", input.Tag, err)
+	}
+	return &release, false, nil
+}
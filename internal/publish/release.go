@@ -0,0 +1,85 @@
+package publish
+
+import (
+	"fmt"
+	"net/url"
+
+	"jira-release-manager/internal/jira"
+	"jira-release-manager/internal/organizer"
+	"jira-release-manager/internal/templates"
+)
+
+// Release contiene i dati di una release da pubblicare su una piattaforma esterna
+type Release struct {
+	templates.Data
+	ProjectKey string
+}
+
+// NewRelease prepara i dati di una release per la pubblicazione
+func NewRelease(version *jira.Version, hierarchy *organizer.ReleaseHierarchy, includeSubtasks bool, baseURL, projectKey string) Release {
+	return Release{
+		Data:       templates.NewData(version, hierarchy, includeSubtasks, baseURL),
+		ProjectKey: projectKey,
+	}
+}
+
+// IssueURL restituisce il link a un ticket in Jira
+func (r Release) IssueURL(key string) string {
+	return fmt.Sprintf("%s/browse/%s", r.BaseURL, key)
+}
+
+// VersionURL restituisce il link alla pagina della versione in Jira
+func (r Release) VersionURL() string {
+	return fmt.Sprintf("%s/projects/%s/versions/%s", r.BaseURL, r.ProjectKey, r.Version.ID)
+}
+
+// SearchURL restituisce il link alla ricerca di tutti i ticket della versione
+func (r Release) SearchURL() string {
+	jql := fmt.Sprintf(`project = "%s" AND fixVersion = "%s"`, r.ProjectKey, r.Version.Name)
+	return fmt.Sprintf("%s/issues/?jql=%s", r.BaseURL, url.QueryEscape(jql))
+}
+
+// TotalIssues restituisce il numero di ticket della release, sub-task esclusi
+func (r Release) TotalIssues() int {
+	total := len(r.Hierarchy.Epics)
+	for _, children := range r.Hierarchy.EpicChildren {
+		total += len(children)
+	}
+	for _, issues := range r.Hierarchy.StandaloneIssues {
+		total += len(issues)
+	}
+	return total
+}
+
+// Section è un gruppo di ticket da mostrare sotto un unico titolo (un epic o un tipo di issue)
+type Section struct {
+	Title  string
+	Epic   *jira.Issue // Valorizzato solo per le sezioni che rappresentano un epic
+	Issues []jira.Issue
+}
+
+// Sections restituisce le sezioni della release nello stesso ordine del changelog:
+// prima gli epic, poi le issue standalone per tipo, infine i sub-task orfani se richiesti.
+func (r Release) Sections() []Section {
+	var sections []Section
+	for _, epic := range r.Hierarchy.SortedEpics() {
+		epic := epic
+		sections = append(sections, Section{
+			Title:  fmt.Sprintf("🎯 %s", epic.Fields.Summary),
+			Epic:   &epic,
+			Issues: r.Hierarchy.EpicChildren[epic.Key],
+		})
+	}
+	for _, issueType := range r.Hierarchy.IssueTypes(templates.PreferredOrder...) {
+		sections = append(sections, Section{
+			Title:  fmt.Sprintf("%s %s", templates.TypeEmoji(issueType), issueType),
+			Issues: r.Hierarchy.StandaloneIssues[issueType],
+		})
+	}
+	if r.IncludeSubtasks {
+		if orphans := r.Hierarchy.OrphanSubtasks(); len(orphans) > 0 {
+			sections = append(sections, Section{Title: "📎 Sub-task Aggiuntivi", Issues: orphans})
+		}
+	}
+	return sections
+}
@@ -0,0 +1,126 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// fakeReleaseAPI simula le API delle release: restituisce 404 finché la release non è stata creata
+func fakeReleaseAPI(t *testing.T, getPath, createPath, updateMethod, updatePath string, requests *[]string) *httptest.Server {
+	created := false
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.Method+" "+r.URL.EscapedPath())
+
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.EscapedPath() == getPath:
+			if !created {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"id":42,"tag_name":"v2.4.0"}`))
+		case r.Method == http.MethodGet && r.URL.EscapedPath() == createPath:
+			// Elenco delle release, usato da GitHub per cercare le bozze
+			_, _ = w.Write([]byte(`[]`))
+		case r.Method == http.MethodPost && r.URL.EscapedPath() == createPath:
+			created = true
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(body)
+		case r.Method == updateMethod && r.URL.EscapedPath() == updatePath:
+			_ = json.NewEncoder(w).Encode(body)
+		default:
+			t.Errorf("richiesta inattesa: %s %s", r.Method, r.URL.EscapedPath())
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+}
+
+func TestGitHubPublishReleaseCreatesThenUpdates(t *testing.T) {
+	var requests []string
+	server := fakeReleaseAPI(t, "/repos/acme/backend/releases/tags/v2.4.0", "/repos/acme/backend/releases",
+		http.MethodPatch, "/repos/acme/backend/releases/42", &requests)
+	defer server.Close()
+
+	client := NewGitHubClient(server.URL, "token")
+	input := ReleaseInput{Tag: "v2.4.0", Name: "2.4.0", Body: "# Changelog"}
+
+	release, created, err := client.PublishRelease(context.Background(), "acme/backend", input)
+	if err != nil || !created || release.Body != "# Changelog" {
+		t.Fatalf("creazione: release=%+v created=%v err=%v", release, created, err)
+	}
+
+	if _, created, err = client.PublishRelease(context.Background(), "acme/backend", input); err != nil || created {
+		t.Fatalf("aggiornamento: created=%v err=%v", created, err)
+	}
+
+	if len(requests) != 5 {
+		t.Errorf("attese 5 richieste, ricevute %v", requests)
+	}
+}
+
+func TestGitHubPublishReleaseUpdatesDraft(t *testing.T) {
+	var requests []string
+	var patch map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/backend/releases/tags/v2.4.0":
+			// Le bozze non sono restituite dalla ricerca per tag
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/backend/releases":
+			_, _ = w.Write([]byte(`[{"id":7,"tag_name":"v2.3.0"},{"id":42,"tag_name":"v2.4.0","draft":true}]`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/repos/acme/backend/releases/42":
+			if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+				t.Error(err)
+			}
+			_, _ = w.Write([]byte(`{"id":42,"tag_name":"v2.4.0","draft":false,"prerelease":true}`))
+		default:
+			t.Errorf("richiesta inattesa: %s %s", r.Method, r.URL.RequestURI())
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := NewGitHubClient(server.URL, "token")
+	// La bozza esistente viene pubblicata come prerelease
+	input := ReleaseInput{Tag: "v2.4.0", Name: "2.4.0", Body: "# Changelog", Prerelease: true}
+	release, created, err := client.PublishRelease(context.Background(), "acme/backend", input)
+	if err != nil || created || release.ID != 42 {
+		t.Fatalf("release=%+v created=%v err=%v", release, created, err)
+	}
+	if want := "GET /repos/acme/backend/releases?per_page=100&page=1"; len(requests) != 3 || requests[1] != want {
+		t.Errorf("richieste %v, attesa la ricerca %q", requests, want)
+	}
+	want := map[string]interface{}{"name": "2.4.0", "body": "# Changelog", "draft": false, "prerelease": true}
+	if !reflect.DeepEqual(patch, want) {
+		t.Errorf("body del PATCH %v, atteso %v", patch, want)
+	}
+}
+
+func TestGitLabPublishReleaseCreatesThenUpdates(t *testing.T) {
+	var requests []string
+	server := fakeReleaseAPI(t, "/projects/acme%2Fbackend/releases/v2.4.0", "/projects/acme%2Fbackend/releases",
+		http.MethodPut, "/projects/acme%2Fbackend/releases/v2.4.0", &requests)
+	defer server.Close()
+
+	client := NewGitLabClient(server.URL, "token")
+	input := ReleaseInput{Tag: "v2.4.0", Name: "2.4.0", Body: "# Changelog", Ref: "main"}
+
+	release, created, err := client.PublishRelease(context.Background(), "acme/backend", input)
+	if err != nil || !created || release.Description != "# Changelog" {
+		t.Fatalf("creazione: release=%+v created=%v err=%v", release, created, err)
+	}
+
+	if _, created, err = client.PublishRelease(context.Background(), "acme/backend", input); err != nil || created {
+		t.Fatalf("aggiornamento: created=%v err=%v", created, err)
+	}
+}
@@ -0,0 +1,156 @@
+package publish
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"jira-release-manager/internal/jira"
+)
+
+// Limiti imposti da Slack ai messaggi Block Kit
+const (
+	SlackMaxBlocks     = 50
+	SlackMaxTextLength = 3000
+	slackMaxHeader     = 150
+)
+
+// SlackMessage è un messaggio Block Kit accettato dagli incoming webhook di Slack
+type SlackMessage struct {
+	Text   string       `json:"text"` // Testo di fallback per notifiche e client senza Block Kit
+	Blocks []SlackBlock `json:"blocks"`
+}
+
+// SlackBlock è un blocco Block Kit (header, section, context o divider)
+type SlackBlock struct {
+	Type     string      `json:"type"`
+	Text     *SlackText  `json:"text,omitempty"`
+	Elements []SlackText `json:"elements,omitempty"`
+}
+
+// SlackText è un oggetto testo Block Kit
+type SlackText struct {
+	Type  string `json:"type"` // plain_text o mrkdwn
+	Text  string `json:"text"`
+	Emoji bool   `json:"emoji,omitempty"`
+}
+
+// NewSlackMessages converte una release in uno o più messaggi Block Kit: un header con i dati
+// della versione e una section per ogni epic e tipo di issue. Le section più lunghe di 3000
+// caratteri vengono spezzate e, oltre i 50 blocchi, il changelog prosegue in messaggi successivi.
+func NewSlackMessages(release Release) []SlackMessage {
+	title := fmt.Sprintf("📋 Changelog - Versione %s", release.Version.Name)
+
+	blocks := []SlackBlock{
+		{Type: "header", Text: &SlackText{Type: "plain_text", Text: truncate(title, slackMaxHeader), Emoji: true}},
+		slackContext(fmt.Sprintf("*Data di rilascio*: %s  |  *Ticket*: %d  |  <%s|Apri la release in Jira>",
+			release.ReleaseDate, release.TotalIssues(), release.VersionURL())),
+	}
+	if release.Version.Description != "" {
+		blocks = append(blocks, slackSection(slackEscape(release.Version.Description)))
+	}
+
+	for _, section := range release.Sections() {
+		blocks = append(blocks, SlackBlock{Type: "divider"})
+
+		heading := fmt.Sprintf("*%s*", slackEscape(section.Title))
+		if section.Epic != nil {
+			heading += fmt.Sprintf("  <%s|%s>", release.IssueURL(section.Epic.Key), section.Epic.Key)
+		}
+		lines := []string{heading}
+		for _, issue := range section.Issues {
+			lines = append(lines, slackIssue(release, issue, "•"))
+			if release.IncludeSubtasks && !issue.Fields.IssueType.Subtask {
+				for _, subtask := range release.Hierarchy.SubtaskMap[issue.Key] {
+					lines = append(lines, slackIssue(release, subtask, "      ◦"))
+				}
+			}
+		}
+		if section.Epic != nil && release.IncludeSubtasks {
+			for _, subtask := range release.Hierarchy.SubtaskMap[section.Epic.Key] {
+				lines = append(lines, slackIssue(release, subtask, "◦"))
+			}
+		}
+
+		for _, text := range splitLines(lines, SlackMaxTextLength) {
+			blocks = append(blocks, slackSection(text))
+		}
+		blocks = append(blocks, slackContext(fmt.Sprintf("%d ticket", len(section.Issues))))
+	}
+
+	return splitSlackMessages(title, blocks)
+}
+
+// splitSlackMessages distribuisce i blocchi in messaggi da al più 50 blocchi.
+// I messaggi successivi al primo iniziano con un contesto che indica la parte.
+func splitSlackMessages(title string, blocks []SlackBlock) []SlackMessage {
+	if len(blocks) <= SlackMaxBlocks {
+		return []SlackMessage{{Text: title, Blocks: blocks}}
+	}
+
+	chunks := [][]SlackBlock{blocks[:SlackMaxBlocks]}
+	for rest := blocks[SlackMaxBlocks:]; len(rest) > 0; {
+		n := min(len(rest), SlackMaxBlocks-1)
+		chunks = append(chunks, rest[:n])
+		rest = rest[n:]
+	}
+
+	messages := make([]SlackMessage, 0, len(chunks))
+	for i, chunk := range chunks {
+		text := fmt.Sprintf("%s (parte %d/%d)", title, i+1, len(chunks))
+		if i > 0 {
+			chunk = append([]SlackBlock{slackContext(slackEscape(text))}, chunk...)
+		}
+		messages = append(messages, SlackMessage{Text: text, Blocks: chunk})
+	}
+	return messages
+}
+
+// splitLines unisce le righe in testi che non superano maxLength caratteri.
+// Una riga più lunga del limite viene troncata.
+func splitLines(lines []string, maxLength int) []string {
+	var texts []string
+	var current strings.Builder
+	for _, line := range lines {
+		line = truncate(line, maxLength)
+		if current.Len() > 0 && utf8.RuneCountInString(current.String())+1+utf8.RuneCountInString(line) > maxLength {
+			texts = append(texts, current.String())
+			current.Reset()
+		}
+		if current.Len() > 0 {
+			current.WriteString("\n")
+		}
+		current.WriteString(line)
+	}
+	if current.Len() > 0 {
+		texts = append(texts, current.String())
+	}
+	return texts
+}
+
+// truncate accorcia un testo a maxLength caratteri, terminandolo con un'ellissi
+func truncate(text string, maxLength int) string {
+	if utf8.RuneCountInString(text) <= maxLength {
+		return text
+	}
+	runes := []rune(text)
+	return string(runes[:maxLength-1]) + "…"
+}
+
+func slackSection(text string) SlackBlock {
+	return SlackBlock{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: text}}
+}
+
+func slackContext(text string) SlackBlock {
+	return SlackBlock{Type: "context", Elements: []SlackText{{Type: "mrkdwn", Text: text}}}
+}
+
+// slackIssue crea la riga di un ticket con link a Jira
+func slackIssue(release Release, issue jira.Issue, bullet string) string {
+	return fmt.Sprintf("%s <%s|%s>: %s", bullet, release.IssueURL(issue.Key), issue.Key, slackEscape(issue.Fields.Summary))
+}
+
+// slackEscape applica l'escape dei caratteri di controllo del formato mrkdwn di Slack
+func slackEscape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
@@ -0,0 +1,56 @@
+package publish
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"jira-release-manager/internal/jira"
+	"jira-release-manager/internal/organizer"
+)
+
+func TestNewSlackMessagesRespectsLimits(t *testing.T) {
+	// 40 tipi di issue con 60 ticket ciascuno dai sommari lunghi: troppi blocchi e section troppo lunghe
+	var issues []jira.Issue
+	for i := 0; i < 40; i++ {
+		for j := 0; j < 60; j++ {
+			issues = append(issues, jira.Issue{
+				Key: fmt.Sprintf("PROJ-%d", i*100+j),
+				Fields: jira.IssueFields{
+					Summary:   fmt.Sprintf("Sommario piuttosto lungo del ticket numero %d della categoria %d", j, i),
+					IssueType: jira.IssueType{Name: fmt.Sprintf("Tipo %02d", i)},
+				},
+			})
+		}
+	}
+	release := NewRelease(&jira.Version{ID: "1", Name: "2.4.0"}, organizer.NewReleaseHierarchy(issues, false), false, "https://jira.example.com", "PROJ")
+
+	messages := NewSlackMessages(release)
+	if len(messages) < 2 {
+		t.Fatalf("attesi più messaggi, ricevuti %d", len(messages))
+	}
+
+	var text strings.Builder
+	for i, message := range messages {
+		if len(message.Blocks) > SlackMaxBlocks {
+			t.Errorf("messaggio %d: %d blocchi oltre il limite", i, len(message.Blocks))
+		}
+		for _, block := range message.Blocks {
+			if block.Text == nil {
+				continue
+			}
+			if utf8.RuneCountInString(block.Text.Text) > SlackMaxTextLength {
+				t.Errorf("messaggio %d: blocco %s di %d caratteri", i, block.Type, utf8.RuneCountInString(block.Text.Text))
+			}
+			text.WriteString(block.Text.Text + "\n")
+		}
+	}
+
+	// Nessun ticket deve andare perso nella suddivisione
+	for _, issue := range issues {
+		if n := strings.Count(text.String(), "|"+issue.Key+">"); n != 1 {
+			t.Errorf("%s compare %d volte", issue.Key, n)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package publish
+
+import (
+	"fmt"
+	"strings"
+
+	"jira-release-manager/internal/jira"
+)
+
+// TeamsMaxPayloadSize è la dimensione massima (in byte) di un messaggio accettato dagli incoming webhook di Teams
+const TeamsMaxPayloadSize = 28 * 1024
+
+// TeamsMessage è il payload accettato dagli incoming webhook di Microsoft Teams
+type TeamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []TeamsAttachment `json:"attachments"`
+}
+
+// TeamsAttachment contiene una Adaptive Card
+type TeamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	ContentURL  *string      `json:"contentUrl"`
+	Content     AdaptiveCard `json:"content"`
+}
+
+// AdaptiveCard è la radice di una Adaptive Card (https://adaptivecards.io)
+type AdaptiveCard struct {
+	Schema  string            `json:"$schema"`
+	Type    string            `json:"type"`
+	Version string            `json:"version"`
+	Body    []interface{}     `json:"body"`
+	Actions []CardAction      `json:"actions,omitempty"`
+	MSTeams map[string]string `json:"msteams,omitempty"`
+}
+
+// TextBlock è un elemento di testo della card; supporta un sottoinsieme di Markdown
+type TextBlock struct {
+	Type     string `json:"type"`
+	Text     string `json:"text"`
+	Wrap     bool   `json:"wrap"`
+	Size     string `json:"size,omitempty"`
+	Weight   string `json:"weight,omitempty"`
+	IsSubtle bool   `json:"isSubtle,omitempty"`
+	Spacing  string `json:"spacing,omitempty"`
+}
+
+// FactSet è una lista di coppie titolo/valore
+type FactSet struct {
+	Type  string `json:"type"`
+	Facts []Fact `json:"facts"`
+}
+
+// Fact è una coppia titolo/valore di un FactSet
+type Fact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// Container raggruppa altri elementi; con un ID può essere mostrato o nascosto da una ToggleVisibility
+type Container struct {
+	Type         string        `json:"type"`
+	ID           string        `json:"id,omitempty"`
+	IsVisible    *bool         `json:"isVisible,omitempty"`
+	Separator    bool          `json:"separator,omitempty"`
+	Spacing      string        `json:"spacing,omitempty"`
+	SelectAction *CardAction   `json:"selectAction,omitempty"`
+	Items        []interface{} `json:"items"`
+}
+
+// CardAction è un'azione della card (apertura di un link o espansione di un container)
+type CardAction struct {
+	Type           string   `json:"type"`
+	Title          string   `json:"title,omitempty"`
+	URL            string   `json:"url,omitempty"`
+	TargetElements []string `json:"targetElements,omitempty"`
+}
+
+// NewTeamsMessage converte una release in un messaggio Teams con una Adaptive Card:
+// un FactSet con i dati della versione, una sezione espandibile per ogni epic e tipo di issue
+// e i pulsanti per aprire la release in Jira.
+func NewTeamsMessage(release Release) TeamsMessage {
+	card := AdaptiveCard{
+		Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+		Type:    "AdaptiveCard",
+		Version: "1.4",
+		MSTeams: map[string]string{"width": "Full"},
+	}
+
+	card.Body = append(card.Body, TextBlock{
+		Type:   "TextBlock",
+		Text:   fmt.Sprintf("📋 Changelog - Versione %s", release.Version.Name),
+		Wrap:   true,
+		Size:   "Large",
+		Weight: "Bolder",
+	})
+	if release.Version.Description != "" {
+		card.Body = append(card.Body, TextBlock{Type: "TextBlock", Text: release.Version.Description, Wrap: true, IsSubtle: true})
+	}
+
+	state := "Non rilasciata"
+	if release.Version.Released {
+		state = "Rilasciata"
+	}
+	card.Body = append(card.Body, FactSet{
+		Type: "FactSet",
+		Facts: []Fact{
+			{Title: "Versione", Value: release.Version.Name},
+			{Title: "Data di rilascio", Value: release.ReleaseDate},
+			{Title: "Stato", Value: state},
+			{Title: "Ticket", Value: fmt.Sprintf("%d", release.TotalIssues())},
+		},
+	})
+
+	for i, section := range release.Sections() {
+		card.Body = append(card.Body, teamsSection(release, section, fmt.Sprintf("section-%d", i+1))...)
+	}
+
+	card.Actions = []CardAction{
+		{Type: "Action.OpenUrl", Title: "Apri la release in Jira", URL: release.VersionURL()},
+		{Type: "Action.OpenUrl", Title: "Tutti i ticket", URL: release.SearchURL()},
+	}
+
+	return TeamsMessage{
+		Type: "message",
+		Attachments: []TeamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content:     card,
+		}},
+	}
+}
+
+// teamsSection crea l'intestazione cliccabile di una sezione e il container (chiuso) con i suoi ticket
+func teamsSection(release Release, section Section, id string) []interface{} {
+	title := fmt.Sprintf("**%s** (%d)", section.Title, len(section.Issues))
+	if section.Epic != nil {
+		title = fmt.Sprintf("**%s** · [%s](%s) (%d)", section.Title, section.Epic.Key, release.IssueURL(section.Epic.Key), len(section.Issues))
+	}
+
+	var items []interface{}
+	for _, issue := range section.Issues {
+		items = append(items, teamsIssue(release, issue, "▪"))
+		if release.IncludeSubtasks && !issue.Fields.IssueType.Subtask {
+			for _, subtask := range release.Hierarchy.SubtaskMap[issue.Key] {
+				items = append(items, teamsIssue(release, subtask, "   ↳"))
+			}
+		}
+	}
+	if section.Epic != nil && release.IncludeSubtasks {
+		for _, subtask := range release.Hierarchy.SubtaskMap[section.Epic.Key] {
+			items = append(items, teamsIssue(release, subtask, "↳"))
+		}
+	}
+	if len(items) == 0 {
+		items = append(items, TextBlock{Type: "TextBlock", Text: "Nessun ticket collegato", Wrap: true, IsSubtle: true})
+	}
+
+	hidden := false
+	return []interface{}{
+		Container{
+			Type:         "Container",
+			Separator:    true,
+			Spacing:      "Medium",
+			SelectAction: &CardAction{Type: "Action.ToggleVisibility", Title: "Espandi", TargetElements: []string{id}},
+			Items:        []interface{}{TextBlock{Type: "TextBlock", Text: title + " ▾", Wrap: true}},
+		},
+		Container{
+			Type:      "Container",
+			ID:        id,
+			IsVisible: &hidden,
+			Items:     items,
+		},
+	}
+}
+
+// teamsIssue crea la riga di un ticket con link a Jira
+func teamsIssue(release Release, issue jira.Issue, bullet string) TextBlock {
+	text := fmt.Sprintf("%s [%s](%s): %s", bullet, issue.Key, release.IssueURL(issue.Key), escapeTeamsMarkdown(issue.Fields.Summary))
+	return TextBlock{Type: "TextBlock", Text: text, Wrap: true, Spacing: "Small"}
+}
+
+// escapeTeamsMarkdown evita che i caratteri speciali del sommario vengano interpretati come Markdown
+func escapeTeamsMarkdown(text string) string {
+	return strings.NewReplacer("*", "\\*", "_", "\\_", "[", "\\[", "]", "\\]").Replace(text)
+}
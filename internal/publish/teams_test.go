@@ -0,0 +1,76 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"jira-release-manager/internal/jira"
+	"jira-release-manager/internal/organizer"
+)
+
+// newTestRelease crea una release con un epic, una story collegata e un bug standalone
+func newTestRelease() Release {
+	issue := func(key, summary, issueType string) jira.Issue {
+		return jira.Issue{Key: key, Fields: jira.IssueFields{Summary: summary, IssueType: jira.IssueType{Name: issueType}}}
+	}
+	story := issue("PROJ-2", "Login", "Story")
+	story.Fields.Parent = &jira.IssueRef{Key: "PROJ-1"}
+
+	hierarchy := organizer.NewReleaseHierarchy([]jira.Issue{
+		issue("PROJ-1", "Autenticazione", "Epic"),
+		story,
+		issue("PROJ-3", "Crash all'avvio", "Bug"),
+	}, false)
+	version := &jira.Version{ID: "10001", Name: "2.4.0", ReleaseDate: "2026-10-12"}
+
+	return NewRelease(version, hierarchy, false, "https://jira.example.com", "PROJ")
+}
+
+func TestPostWebhookTeamsMessage(t *testing.T) {
+	var received TeamsMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, atteso application/json", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("payload non valido: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	if err := PostWebhook(context.Background(), server.URL, NewTeamsMessage(newTestRelease())); err != nil {
+		t.Fatalf("PostWebhook: %v", err)
+	}
+
+	if len(received.Attachments) != 1 {
+		t.Fatalf("attesi 1 allegato, ricevuti %d", len(received.Attachments))
+	}
+	card := received.Attachments[0].Content
+	if card.Type != "AdaptiveCard" {
+		t.Errorf("tipo della card = %q", card.Type)
+	}
+	// Titolo, FactSet e due container (intestazione + contenuto) per l'epic e per i Bug
+	if len(card.Body) != 6 {
+		t.Errorf("attesi 6 elementi nel body, ricevuti %d", len(card.Body))
+	}
+	if len(card.Actions) != 2 || card.Actions[0].URL != "https://jira.example.com/projects/PROJ/versions/10001" {
+		t.Errorf("azioni inattese: %+v", card.Actions)
+	}
+}
+
+func TestPostWebhookError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+	}))
+	defer server.Close()
+
+	err := PostWebhook(context.Background(), server.URL, NewTeamsMessage(newTestRelease()))
+	if err == nil || !strings.Contains(err.Error(), "413") {
+		t.Fatalf("atteso errore HTTP 413, ottenuto %v", err)
+	}
+}
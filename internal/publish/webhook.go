@@ -0,0 +1,55 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// defaultHTTPClient è il client usato per le chiamate verso le piattaforme di pubblicazione
+var defaultHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// PostWebhook invia un payload JSON a un incoming webhook e restituisce un errore
+// se il destinatario risponde con uno stato diverso da 2xx.
+func PostWebhook(ctx context.Context, webhookURL string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("errore nella serializzazione del payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("errore nella creazione della richiesta: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := defaultHTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("errore nell'invio al webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("il webhook ha risposto con errore HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+// MarshalIndent serializza un payload in JSON indentato, senza l'escape di <, > e & che
+// renderebbe illeggibili i link di Slack nell'anteprima
+func MarshalIndent(payload interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(payload); err != nil {
+		return nil, fmt.Errorf("errore nella serializzazione del payload: %w", err)
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
@@ -0,0 +1,369 @@
+package readiness
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"jira-release-manager/internal/jira"
+)
+
+// Identificativi delle regole valutate, usati nel flag --rules
+const (
+	RuleBlocked    = "blocked"
+	RuleUnassigned = "unassigned"
+	RuleSubtasks   = "subtasks"
+	RuleBugs       = "bugs"
+	RuleLabels     = "labels"
+)
+
+// Rules elenca le regole nell'ordine in cui vengono valutate e mostrate
+var Rules = []string{RuleBlocked, RuleUnassigned, RuleSubtasks, RuleBugs, RuleLabels}
+
+// Level indica come viene trattata la violazione di una regola
+type Level string
+
+const (
+	// LevelFail fa fallire la verifica
+	LevelFail Level = "fail"
+	// LevelWarn segnala la violazione senza far fallire la verifica
+	LevelWarn Level = "warn"
+	// LevelOff disattiva la regola
+	LevelOff Level = "off"
+)
+
+// DefaultLevels sono i livelli delle regole se --rules non li modifica
+var DefaultLevels = map[string]Level{
+	RuleBlocked:    LevelFail,
+	RuleUnassigned: LevelWarn,
+	RuleSubtasks:   LevelFail,
+	RuleBugs:       LevelFail,
+	RuleLabels:     LevelWarn,
+}
+
+// Outcome è l'esito di una regola o dell'intera verifica
+type Outcome string
+
+const (
+	OutcomePass Outcome = "pass"
+	OutcomeWarn Outcome = "warn"
+	OutcomeFail Outcome = "fail"
+	OutcomeSkip Outcome = "skip" // Regola disattivata
+)
+
+// priorityRanks ordina le priorità degli schemi predefiniti di Jira Cloud e di Jira Server,
+// usate quando l'ordine delle priorità di Jira non è disponibile
+var priorityRanks = map[string]int{
+	"highest": 5, "blocker": 5,
+	"high": 4, "critical": 4,
+	"medium": 3, "major": 3,
+	"low": 2, "minor": 2,
+	"lowest": 1, "trivial": 1,
+}
+
+// priorityRank restituisce il peso di una priorità (più alto = più urgente). Con priorities
+// (dalla più alla meno urgente, come le restituisce Jira) il peso deriva dalla posizione nella lista,
+// altrimenti dagli schemi predefiniti.
+func priorityRank(name string, priorities []string) (int, bool) {
+	name = strings.TrimSpace(name)
+	if len(priorities) == 0 {
+		rank, ok := priorityRanks[strings.ToLower(name)]
+		return rank, ok
+	}
+	for i, priority := range priorities {
+		if strings.EqualFold(priority, name) {
+			return len(priorities) - i, true
+		}
+	}
+	return 0, false
+}
+
+// ParseLevels interpreta una lista "regola=livello" separata da virgole (es. "labels=off,unassigned=fail"),
+// applicandola sopra DefaultLevels.
+func ParseLevels(value string) (map[string]Level, error) {
+	levels := make(map[string]Level, len(DefaultLevels))
+	for rule, level := range DefaultLevels {
+		levels[rule] = level
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		rule, level, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("regola non valida: %q (formato atteso: regola=livello)", entry)
+		}
+		rule = strings.ToLower(strings.TrimSpace(rule))
+		if !slices.Contains(Rules, rule) {
+			return nil, fmt.Errorf("regola sconosciuta: %q (valori ammessi: %s)", rule, strings.Join(Rules, ", "))
+		}
+		switch l := Level(strings.ToLower(strings.TrimSpace(level))); l {
+		case LevelFail, LevelWarn, LevelOff:
+			levels[rule] = l
+		default:
+			return nil, fmt.Errorf("livello non valido per la regola %s: %q (valori ammessi: fail, warn, off)", rule, level)
+		}
+	}
+	return levels, nil
+}
+
+// Options contiene la configurazione delle regole
+type Options struct {
+	Levels          map[string]Level // Livello di ogni regola (default: DefaultLevels)
+	BlockedStatuses []string         // Stati considerati bloccanti
+	HighPriority    string           // Priorità minima per cui un ticket aperto deve avere un assegnatario
+	MaxBugPriority  string           // Priorità massima ammessa per i bug aperti
+	BugTypes        []string         // Tipi di issue considerati bug
+	RepoLabels      []string         // Etichette di repository ammesse; se vuoto qualsiasi etichetta è valida
+	SubtaskTypes    []string         // Tipi di issue considerati sub-task, oltre a quelli marcati come tali da Jira
+	Priorities      []string         // Priorità di Jira dalla più alla meno urgente; se vuoto si usano gli schemi predefiniti
+}
+
+// ValidatePriority controlla che una priorità usata come soglia sia tra priorities o, se la lista
+// è vuota, negli schemi predefiniti
+func ValidatePriority(name string, priorities []string) error {
+	if _, ok := priorityRank(name, priorities); ok {
+		return nil
+	}
+	if len(priorities) == 0 {
+		return fmt.Errorf("priorità sconosciuta: %q (valori ammessi: Highest, High, Medium, Low, Lowest o Blocker, Critical, Major, Minor, Trivial)", name)
+	}
+	return fmt.Errorf("priorità sconosciuta: %q (priorità di Jira: %s)", name, strings.Join(priorities, ", "))
+}
+
+// Validate controlla che le soglie di priorità siano riconosciute
+func (o Options) Validate() error {
+	if err := ValidatePriority(o.HighPriority, o.Priorities); err != nil {
+		return err
+	}
+	return ValidatePriority(o.MaxBugPriority, o.Priorities)
+}
+
+// Violation è un ticket che non rispetta una regola
+type Violation struct {
+	Issue  jira.Issue
+	Detail string
+}
+
+// RuleResult è l'esito di una singola regola
+type RuleResult struct {
+	Rule        string
+	Description string
+	Level       Level
+	Outcome     Outcome
+	Violations  []Violation
+}
+
+// Report è l'esito complessivo della verifica
+type Report struct {
+	Issues  int // Numero di ticket valutati
+	Results []RuleResult
+	Outcome Outcome
+}
+
+// Count restituisce il numero di regole con l'esito indicato
+func (r Report) Count(outcome Outcome) int {
+	count := 0
+	for _, result := range r.Results {
+		if result.Outcome == outcome {
+			count++
+		}
+	}
+	return count
+}
+
+// Evaluate valuta le regole sui ticket di una versione
+func Evaluate(issues []jira.Issue, opts Options) Report {
+	levels := opts.Levels
+	if levels == nil {
+		levels = DefaultLevels
+	}
+
+	checks := map[string]struct {
+		description string
+		check       func([]jira.Issue, Options) []Violation
+	}{
+		RuleBlocked: {
+			fmt.Sprintf("Nessun ticket in stato bloccante (%s)", strings.Join(opts.BlockedStatuses, ", ")),
+			checkBlocked,
+		},
+		RuleUnassigned: {
+			fmt.Sprintf("Nessun ticket aperto con priorità %s o superiore senza assegnatario", opts.HighPriority),
+			checkUnassigned,
+		},
+		RuleSubtasks: {
+			"Tutti i sub-task completati",
+			checkSubtasks,
+		},
+		RuleBugs: {
+			fmt.Sprintf("Nessun bug aperto con priorità superiore a %s", opts.MaxBugPriority),
+			checkBugs,
+		},
+		RuleLabels: {
+			"Ogni ticket ha un'etichetta di repository",
+			checkLabels,
+		},
+	}
+
+	report := Report{Issues: len(issues), Outcome: OutcomePass}
+	for _, rule := range Rules {
+		result := RuleResult{
+			Rule:        rule,
+			Description: checks[rule].description,
+			Level:       levels[rule],
+			Outcome:     OutcomePass,
+		}
+
+		if result.Level == LevelOff {
+			result.Outcome = OutcomeSkip
+		} else if result.Violations = checks[rule].check(issues, opts); len(result.Violations) > 0 {
+			result.Outcome = Outcome(result.Level)
+		}
+
+		switch {
+		case result.Outcome == OutcomeFail:
+			report.Outcome = OutcomeFail
+		case result.Outcome == OutcomeWarn && report.Outcome == OutcomePass:
+			report.Outcome = OutcomeWarn
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report
+}
+
+// checkBlocked segnala i ticket in uno degli stati bloccanti
+func checkBlocked(issues []jira.Issue, opts Options) []Violation {
+	var violations []Violation
+	for _, issue := range issues {
+		if containsFold(opts.BlockedStatuses, issue.Fields.Status.Name) {
+			violations = append(violations, Violation{Issue: issue, Detail: issue.Fields.Status.Name})
+		}
+	}
+	return violations
+}
+
+// checkUnassigned segnala i ticket aperti ad alta priorità senza assegnatario. Una priorità non
+// riconosciuta è segnalata a sua volta, perché non si può escludere che superi la soglia.
+func checkUnassigned(issues []jira.Issue, opts Options) []Violation {
+	threshold, _ := priorityRank(opts.HighPriority, opts.Priorities)
+
+	var violations []Violation
+	for _, issue := range issues {
+		if issue.IsCompleted() || issue.Fields.Assignee != nil || issue.Fields.Priority == nil {
+			continue
+		}
+		rank, ok := priorityRank(issue.Fields.Priority.Name, opts.Priorities)
+		switch {
+		case !ok:
+			violations = append(violations, Violation{Issue: issue, Detail: unknownPriorityDetail(issue)})
+		case rank >= threshold:
+			violations = append(violations, Violation{Issue: issue, Detail: "priorità " + issue.Fields.Priority.Name})
+		}
+	}
+	return violations
+}
+
+// unknownPriorityDetail descrive un ticket con una priorità non riconosciuta
+func unknownPriorityDetail(issue jira.Issue) string {
+	return fmt.Sprintf("priorità %s non riconosciuta", issue.Fields.Priority.Name)
+}
+
+// checkSubtasks segnala i sub-task non completati, sia recuperati sia citati dai ticket padre
+func checkSubtasks(issues []jira.Issue, opts Options) []Violation {
+	var violations []Violation
+	seen := make(map[string]bool)
+
+	for _, issue := range issues {
+		if !isSubtask(issue, opts) || seen[issue.Key] {
+			continue
+		}
+		seen[issue.Key] = true
+		if !issue.IsCompleted() {
+			violations = append(violations, Violation{Issue: issue, Detail: subtaskDetail(issue)})
+		}
+	}
+
+	// Sub-task che non è stato possibile recuperare: si usa lo stato riportato dal padre
+	for _, parent := range issues {
+		for _, ref := range parent.Fields.Subtasks {
+			if seen[ref.Key] || ref.Fields == nil {
+				continue
+			}
+			seen[ref.Key] = true
+			subtask := jira.Issue{ID: ref.ID, Key: ref.Key, Fields: *ref.Fields}
+			subtask.Fields.Parent = &jira.IssueRef{ID: parent.ID, Key: parent.Key}
+			if !subtask.IsCompleted() {
+				violations = append(violations, Violation{Issue: subtask, Detail: subtaskDetail(subtask)})
+			}
+		}
+	}
+	return violations
+}
+
+// subtaskDetail descrive un sub-task non completato
+func subtaskDetail(subtask jira.Issue) string {
+	if subtask.Fields.Parent != nil {
+		return fmt.Sprintf("%s, sub-task di %s", subtask.Fields.Status.Name, subtask.Fields.Parent.Key)
+	}
+	return subtask.Fields.Status.Name
+}
+
+// checkBugs segnala i bug aperti con priorità superiore alla soglia o non riconosciuta
+func checkBugs(issues []jira.Issue, opts Options) []Violation {
+	threshold, _ := priorityRank(opts.MaxBugPriority, opts.Priorities)
+
+	var violations []Violation
+	for _, issue := range issues {
+		if issue.IsCompleted() || issue.Fields.Priority == nil || !containsFold(opts.BugTypes, issue.Fields.IssueType.Name) {
+			continue
+		}
+		rank, ok := priorityRank(issue.Fields.Priority.Name, opts.Priorities)
+		switch {
+		case !ok:
+			violations = append(violations, Violation{Issue: issue, Detail: unknownPriorityDetail(issue)})
+		case rank > threshold:
+			violations = append(violations, Violation{
+				Issue:  issue,
+				Detail: fmt.Sprintf("priorità %s, %s", issue.Fields.Priority.Name, issue.Fields.Status.Name),
+			})
+		}
+	}
+	return violations
+}
+
+// checkLabels segnala i ticket senza un'etichetta di repository. Epic e sub-task sono esclusi,
+// perché di norma non corrispondono a un singolo repository.
+func checkLabels(issues []jira.Issue, opts Options) []Violation {
+	var violations []Violation
+	for _, issue := range issues {
+		if strings.EqualFold(issue.Fields.IssueType.Name, "epic") || isSubtask(issue, opts) {
+			continue
+		}
+		if len(issue.Fields.Labels) == 0 {
+			violations = append(violations, Violation{Issue: issue, Detail: "nessuna etichetta"})
+			continue
+		}
+		if len(opts.RepoLabels) == 0 {
+			continue
+		}
+		if !slices.ContainsFunc(issue.Fields.Labels, func(label string) bool { return slices.Contains(opts.RepoLabels, label) }) {
+			violations = append(violations, Violation{
+				Issue:  issue,
+				Detail: "nessuna etichetta di repository tra: " + strings.Join(issue.Fields.Labels, ", "),
+			})
+		}
+	}
+	return violations
+}
+
+// isSubtask indica se un ticket è un sub-task
+func isSubtask(issue jira.Issue, opts Options) bool {
+	return issue.Fields.IssueType.Subtask || containsFold(opts.SubtaskTypes, issue.Fields.IssueType.Name)
+}
+
+// containsFold verifica se values contiene value, ignorando maiuscole e minuscole
+func containsFold(values []string, value string) bool {
+	return slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, value) })
+}
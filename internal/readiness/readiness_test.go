@@ -0,0 +1,132 @@
+package readiness
+
+import (
+	"testing"
+
+	"jira-release-manager/internal/jira"
+)
+
+func issue(key, issueType, status, category, priority string) jira.Issue {
+	i := jira.Issue{Key: key, Fields: jira.IssueFields{
+		Summary:   "Summary of " + key,
+		IssueType: jira.IssueType{Name: issueType, Subtask: issueType == "Sub-task"},
+		Status:    jira.Status{Name: status, StatusCategory: jira.StatusCategory{Key: category}},
+		Labels:    []string{"api"},
+	}}
+	if priority != "" {
+		i.Fields.Priority = &jira.Priority{Name: priority}
+	}
+	return i
+}
+
+func defaultOptions() Options {
+	return Options{
+		BlockedStatuses: []string{"Blocked"},
+		HighPriority:    "High",
+		MaxBugPriority:  "Medium",
+		BugTypes:        []string{"Bug"},
+	}
+}
+
+func violationKeys(result RuleResult) []string {
+	var keys []string
+	for _, v := range result.Violations {
+		keys = append(keys, v.Issue.Key)
+	}
+	return keys
+}
+
+func TestEvaluate(t *testing.T) {
+	story := issue("PROJ-1", "Story", "Done", "done", "High")
+	story.Fields.Assignee = &jira.User{DisplayName: "Mario"}
+	story.Fields.Subtasks = []jira.IssueRef{
+		{Key: "PROJ-2"},
+		{Key: "PROJ-9", Fields: &jira.IssueFields{Status: jira.Status{Name: "In Progress", StatusCategory: jira.StatusCategory{Key: "indeterminate"}}}},
+	}
+	subtask := issue("PROJ-2", "Sub-task", "Done", "done", "")
+	blockedBug := issue("PROJ-3", "Bug", "Blocked", "indeterminate", "Critical")
+	minorBug := issue("PROJ-4", "Bug", "To Do", "new", "Medium")
+	minorBug.Fields.Labels = nil
+	epic := issue("PROJ-5", "Epic", "In Progress", "indeterminate", "Low")
+	epic.Fields.Labels = nil
+
+	report := Evaluate([]jira.Issue{story, subtask, blockedBug, minorBug, epic}, defaultOptions())
+
+	want := map[string][]string{
+		RuleBlocked:    {"PROJ-3"},
+		RuleUnassigned: {"PROJ-3"},
+		RuleSubtasks:   {"PROJ-9"},
+		RuleBugs:       {"PROJ-3"},
+		RuleLabels:     {"PROJ-4"},
+	}
+	for _, result := range report.Results {
+		got := violationKeys(result)
+		if len(got) != len(want[result.Rule]) || (len(got) > 0 && got[0] != want[result.Rule][0]) {
+			t.Errorf("regola %s: violazioni %v, attese %v", result.Rule, got, want[result.Rule])
+		}
+	}
+	if report.Outcome != OutcomeFail {
+		t.Errorf("esito %s, atteso fail", report.Outcome)
+	}
+}
+
+func TestEvaluateLevels(t *testing.T) {
+	levels, err := ParseLevels("blocked=warn, bugs=off")
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := defaultOptions()
+	opts.Levels = levels
+
+	report := Evaluate([]jira.Issue{issue("PROJ-1", "Bug", "Blocked", "indeterminate", "Highest")}, opts)
+	if report.Outcome != OutcomeWarn {
+		t.Errorf("esito %s, atteso warn", report.Outcome)
+	}
+	if got := report.Count(OutcomeSkip); got != 1 {
+		t.Errorf("%d regole disattivate, attesa 1", got)
+	}
+}
+
+func TestParseLevelsErrors(t *testing.T) {
+	for _, value := range []string{"unknown=fail", "blocked", "blocked=maybe"} {
+		if _, err := ParseLevels(value); err == nil {
+			t.Errorf("ParseLevels(%q): errore atteso", value)
+		}
+	}
+}
+
+func TestEvaluatePriorities(t *testing.T) {
+	issues := []jira.Issue{
+		issue("PROJ-1", "Bug", "To Do", "new", "P1"),
+		issue("PROJ-2", "Bug", "To Do", "new", "P3"),
+		issue("PROJ-3", "Bug", "To Do", "new", "Urgente"),
+	}
+	bugs := func(opts Options) []string {
+		for _, result := range Evaluate(issues, opts).Results {
+			if result.Rule == RuleBugs {
+				return violationKeys(result)
+			}
+		}
+		return nil
+	}
+
+	// Senza le priorità di Jira quelle personalizzate non sono riconosciute e vengono segnalate
+	if got := bugs(defaultOptions()); len(got) != 3 {
+		t.Errorf("schemi predefiniti: violazioni %v, attese 3", got)
+	}
+
+	opts := defaultOptions()
+	opts.Priorities = []string{"P1", "P2", "P3", "P4"}
+	opts.HighPriority, opts.MaxBugPriority = "P2", "P2"
+	if err := opts.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if got := bugs(opts); len(got) != 2 || got[0] != "PROJ-1" || got[1] != "PROJ-3" {
+		t.Errorf("priorità di Jira: violazioni %v, attese [PROJ-1 PROJ-3]", got)
+	}
+
+	opts.MaxBugPriority = "Medium"
+	if err := opts.Validate(); err == nil {
+		t.Error("Validate: errore atteso per una soglia assente dalle priorità di Jira")
+	}
+}
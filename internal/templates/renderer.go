@@ -1,273 +1,313 @@
 package templates
 
 import (
+	"embed"
 	"fmt"
+	"hash/fnv"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+	"text/template"
 	"time"
+	"unicode"
 
 	"jira-release-manager/internal/jira"
 	"jira-release-manager/internal/organizer"
 )
 
-// RenderMarkdown genera un changelog in formato Markdown
-func RenderMarkdown(version *jira.Version, hierarchy *organizer.ReleaseHierarchy, includeSubtasks bool, baseURL string) string {
-	var sb strings.Builder
+//go:embed defaults/*.tmpl
+var defaultTemplates embed.FS
+
+// PreferredOrder è l'ordine con cui vengono mostrati i tipi di issue standalone
+var PreferredOrder = []string{"Story", "Task", "Improvement", "Bug"}
+
+// typeEmojis associa un'icona ai tipi di issue più comuni
+var typeEmojis = map[string]string{
+	"Story":       "✨",
+	"Task":        "📝",
+	"Improvement": "🔧",
+	"Bug":         "🐛",
+}
+
+// Data contiene i dati passati ai template del changelog
+type Data struct {
+	Version         *jira.Version
+	Hierarchy       *organizer.ReleaseHierarchy
+	IncludeSubtasks bool
+	BaseURL         string
+	ReleaseDate     string // Data di rilascio della versione, o la data odierna se assente
+}
 
-	sb.WriteString(fmt.Sprintf("# 📋 Changelog - Versione %s\n\n", version.Name))
+// Group rappresenta un insieme di issue raggruppate per tipo, etichetta o componente
+type Group struct {
+	Name   string
+	Issues []jira.Issue
+}
 
+// NewData prepara i dati per il rendering di una release
+func NewData(version *jira.Version, hierarchy *organizer.ReleaseHierarchy, includeSubtasks bool, baseURL string) Data {
 	releaseDate := time.Now().Format("2006-01-02")
 	if version.ReleaseDate != "" {
 		releaseDate = version.ReleaseDate
 	}
-	sb.WriteString(fmt.Sprintf("**Data di rilascio**: %s\n\n", releaseDate))
 
-	if version.Description != "" {
-		sb.WriteString(fmt.Sprintf("**Descrizione**: %s\n\n", version.Description))
+	return Data{
+		Version:         version,
+		Hierarchy:       hierarchy,
+		IncludeSubtasks: includeSubtasks,
+		BaseURL:         baseURL,
+		ReleaseDate:     releaseDate,
 	}
+}
 
-	sb.WriteString("---\n\n")
-
-	epics := hierarchy.Epics
-	epicChildren := hierarchy.EpicChildren
-	standaloneIssues := hierarchy.StandaloneIssues
-	subtaskMap := hierarchy.SubtaskMap
-	printedSubtasks := make(map[string]bool)
-
-	if len(epics) > 0 {
-		sb.WriteString("## 🎯 Epic\n\n")
-		for _, epic := range epics {
-			issueURL := fmt.Sprintf("%s/browse/%s", baseURL, epic.Key)
-			sb.WriteString(fmt.Sprintf("### **[%s](%s)** %s\n\n", epic.Key, issueURL, epic.Fields.Summary))
-
-			if children, ok := epicChildren[epic.Key]; ok && len(children) > 0 {
-				for _, child := range children {
-					childURL := fmt.Sprintf("%s/browse/%s", baseURL, child.Key)
-					sb.WriteString(fmt.Sprintf("- **[%s](%s)**: %s\n", child.Key, childURL, child.Fields.Summary))
-
-					if includeSubtasks {
-						if subtasks, ok := subtaskMap[child.Key]; ok {
-							for _, subtask := range subtasks {
-								subtaskURL := fmt.Sprintf("%s/browse/%s", baseURL, subtask.Key)
-								sb.WriteString(fmt.Sprintf("  - [%s](%s): %s\n", subtask.Key, subtaskURL, subtask.Fields.Summary))
-								printedSubtasks[subtask.Key] = true
-							}
-						}
-					}
-				}
-				sb.WriteString("\n")
-			}
-		}
-	}
+// Template è un template compilato. Il formato html usa html/template, che applica l'escaping
+// in base al contesto; gli altri formati e i template personalizzati usano text/template.
+type Template struct {
+	text *template.Template
+	html *htmltemplate.Template
+}
 
-	preferredOrder := []string{"Story", "Task", "Improvement", "Bug"}
-	typeEmoji := map[string]string{
-		"Story":       "✨",
-		"Task":        "📝",
-		"Improvement": "🔧",
-		"Bug":         "🐛",
+// Name restituisce il nome del template
+func (t *Template) Name() string {
+	if t.html != nil {
+		return t.html.Name()
 	}
+	return t.text.Name()
+}
 
-	for _, issueType := range preferredOrder {
-		issuesList, ok := standaloneIssues[issueType]
-		if !ok || len(issuesList) == 0 {
-			continue
-		}
-
-		emoji := typeEmoji[issueType]
-		if emoji == "" {
-			emoji = "•"
+// execute esegue una copia del template con le funzioni helper legate ai dati della release.
+// Il template originale non viene mai eseguito, così può essere riusato (html/template non
+// permette di clonare un template già eseguito).
+func (t *Template) execute(w io.Writer, release Data, data interface{}) error {
+	if t.html != nil {
+		bound, err := t.html.Clone()
+		if err != nil {
+			return err
 		}
+		return bound.Funcs(htmltemplate.FuncMap(funcMap(release))).Execute(w, data)
+	}
 
-		sb.WriteString(fmt.Sprintf("## %s %s\n\n", emoji, issueType))
-
-		for _, issue := range issuesList {
-			issueURL := fmt.Sprintf("%s/browse/%s", baseURL, issue.Key)
-			sb.WriteString(fmt.Sprintf("- **[%s](%s)**: %s\n", issue.Key, issueURL, issue.Fields.Summary))
-
-			if includeSubtasks {
-				if subtasks, ok := subtaskMap[issue.Key]; ok {
-					for _, subtask := range subtasks {
-						subtaskURL := fmt.Sprintf("%s/browse/%s", baseURL, subtask.Key)
-						sb.WriteString(fmt.Sprintf("  - [%s](%s): %s\n", subtask.Key, subtaskURL, subtask.Fields.Summary))
-						printedSubtasks[subtask.Key] = true
-					}
-				}
-			}
-		}
-		sb.WriteString("\n")
+	bound, err := t.text.Clone()
+	if err != nil {
+		return err
 	}
+	return bound.Funcs(funcMap(release)).Execute(w, data)
+}
 
-	for issueType, issuesList := range standaloneIssues {
-		found := false
-		for _, preferred := range preferredOrder {
-			if issueType == preferred {
-				found = true
-				break
-			}
-		}
-		if !found && len(issuesList) > 0 {
-			sb.WriteString(fmt.Sprintf("## • %s\n\n", issueType))
-			for _, issue := range issuesList {
-				issueURL := fmt.Sprintf("%s/browse/%s", baseURL, issue.Key)
-				sb.WriteString(fmt.Sprintf("- **[%s](%s)**: %s\n", issue.Key, issueURL, issue.Fields.Summary))
-			}
-			sb.WriteString("\n")
-		}
+// Builtin restituisce il template predefinito per un formato (markdown, teams, html)
+func Builtin(format string) (*Template, error) {
+	name := format + ".tmpl"
+	content, err := defaultTemplates.ReadFile("defaults/" + name)
+	if err != nil {
+		return nil, fmt.Errorf("formato di changelog non supportato: %s", format)
+	}
+	if format == "html" {
+		return parseHTML(name, string(content))
 	}
+	return parse(name, string(content))
+}
 
-	var orphanedSubtasks []jira.Issue
-	if includeSubtasks {
-		for _, subtasks := range subtaskMap {
-			for _, subtask := range subtasks {
-				if _, printed := printedSubtasks[subtask.Key]; !printed {
-					orphanedSubtasks = append(orphanedSubtasks, subtask)
-				}
-			}
-		}
+// FromFile carica un template definito dall'utente
+func FromFile(path string) (*Template, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("impossibile leggere il template %s: %w", path, err)
 	}
+	return parse(filepath.Base(path), string(content))
+}
 
-	if len(orphanedSubtasks) > 0 {
-		sb.WriteString("## 📎 Sub-task Aggiuntivi\n\n")
-		sb.WriteString("*(Ticket con fixVersion, ma genitore non in questa release o completato)*\n\n")
-		for _, subtask := range orphanedSubtasks {
-			subtaskURL := fmt.Sprintf("%s/browse/%s", baseURL, subtask.Key)
-			sb.WriteString(fmt.Sprintf("- [%s](%s): %s\n", subtask.Key, subtaskURL, subtask.Fields.Summary))
-		}
-		sb.WriteString("\n")
+// Render esegue il template sui dati della release
+func Render(tmpl *Template, data Data) (string, error) {
+	var sb strings.Builder
+	if err := tmpl.execute(&sb, data, data); err != nil {
+		return "", fmt.Errorf("errore nell'esecuzione del template %s: %w", tmpl.Name(), err)
 	}
+	return sb.String(), nil
+}
+
+// DefaultComment è il template predefinito del commento di rilascio sui ticket
+const DefaultComment = `Shipped in v{{ trimPrefix "v" .Version.Name }} on {{ .ReleaseDate }}`
 
-	return sb.String()
+// CommentData contiene i dati passati al template del commento di rilascio: quelli della release
+// e il ticket su cui viene pubblicato
+type CommentData struct {
+	Data
+	Issue jira.Issue
 }
 
-// RenderTeams genera un changelog in formato Markdown per Microsoft Teams
-func RenderTeams(version *jira.Version, hierarchy *organizer.ReleaseHierarchy, includeSubtasks bool, baseURL string) string {
+// ParseComment compila il template di un commento passato come testo
+func ParseComment(content string) (*Template, error) {
+	return parse("comment", content)
+}
+
+// RenderComment esegue il template del commento per un ticket
+func RenderComment(tmpl *Template, data CommentData) (string, error) {
 	var sb strings.Builder
+	if err := tmpl.execute(&sb, data.Data, data); err != nil {
+		return "", fmt.Errorf("errore nell'esecuzione del template %s per %s: %w", tmpl.Name(), data.Issue.Key, err)
+	}
+	return sb.String(), nil
+}
 
-	sb.WriteString(fmt.Sprintf("**📋 Changelog - Versione %s**\n\n", version.Name))
+// parse compila un template text/template registrando le funzioni helper
+func parse(name, content string) (*Template, error) {
+	tmpl, err := template.New(name).Funcs(funcMap(Data{})).Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("errore nel parsing del template %s: %w", name, err)
+	}
+	return &Template{text: tmpl}, nil
+}
 
-	releaseDate := time.Now().Format("2006-01-02")
-	if version.ReleaseDate != "" {
-		releaseDate = version.ReleaseDate
+// parseHTML compila un template html/template registrando le funzioni helper
+func parseHTML(name, content string) (*Template, error) {
+	tmpl, err := htmltemplate.New(name).Funcs(htmltemplate.FuncMap(funcMap(Data{}))).Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("errore nel parsing del template %s: %w", name, err)
 	}
-	sb.WriteString(fmt.Sprintf("**Data di rilascio**: %s\n\n", releaseDate))
+	return &Template{html: tmpl}, nil
+}
 
-	if version.Description != "" {
-		sb.WriteString(fmt.Sprintf("**Descrizione**: %s\n\n", version.Description))
+// funcMap costruisce le funzioni helper disponibili nei template, legate ai dati della release
+func funcMap(data Data) template.FuncMap {
+	hierarchy := data.Hierarchy
+	if hierarchy == nil {
+		hierarchy = organizer.NewReleaseHierarchy(nil, false)
 	}
 
-	sb.WriteString("---\n\n")
-
-	epics := hierarchy.Epics
-	epicChildren := hierarchy.EpicChildren
-	standaloneIssues := hierarchy.StandaloneIssues
-	subtaskMap := hierarchy.SubtaskMap
-	printedSubtasks := make(map[string]bool)
-
-	if len(epics) > 0 {
-		sb.WriteString("**🎯 Epic**\n\n")
-		for _, epic := range epics {
-			issueURL := fmt.Sprintf("%s/browse/%s", baseURL, epic.Key)
-			// Titolo Epic in grassetto
-			sb.WriteString(fmt.Sprintf("**[%s](%s)** %s\n\n", epic.Key, issueURL, epic.Fields.Summary))
-
-			if children, ok := epicChildren[epic.Key]; ok && len(children) > 0 {
-				for _, child := range children {
-					childURL := fmt.Sprintf("%s/browse/%s", baseURL, child.Key)
-					// Elenco puntato per i figli
-					sb.WriteString(fmt.Sprintf("* **[%s](%s)**: %s\n", child.Key, childURL, child.Fields.Summary))
-
-					if includeSubtasks {
-						if subtasks, ok := subtaskMap[child.Key]; ok {
-							for _, subtask := range subtasks {
-								subtaskURL := fmt.Sprintf("%s/browse/%s", baseURL, subtask.Key)
-								// Sotto-elenco per subtask
-								sb.WriteString(fmt.Sprintf("  * [%s](%s): %s\n", subtask.Key, subtaskURL, subtask.Fields.Summary))
-								printedSubtasks[subtask.Key] = true
-							}
-						}
-					}
-				}
-				sb.WriteString("\n")
+	return template.FuncMap{
+		// Navigazione della gerarchia
+		"epics": hierarchy.SortedEpics,
+		"children": func(key string) []jira.Issue {
+			return hierarchy.EpicChildren[key]
+		},
+		"subtasks": func(key string) []jira.Issue {
+			return hierarchy.SubtaskMap[key]
+		},
+		"standalone": func() []Group {
+			var groups []Group
+			for _, issueType := range hierarchy.IssueTypes(PreferredOrder...) {
+				groups = append(groups, Group{Name: issueType, Issues: hierarchy.StandaloneIssues[issueType]})
 			}
-		}
+			return groups
+		},
+		"orphanSubtasks": hierarchy.OrphanSubtasks,
+		"allIssues": func() []jira.Issue {
+			return AllIssues(hierarchy)
+		},
+
+		// Raggruppamenti su una lista di issue
+		"groupByType": func(issues []jira.Issue) []Group {
+			return groupBy(issues, func(issue jira.Issue) []string {
+				return []string{issue.Fields.IssueType.Name}
+			})
+		},
+		"groupByLabel": func(issues []jira.Issue) []Group {
+			return groupBy(issues, func(issue jira.Issue) []string {
+				return issue.Fields.Labels
+			})
+		},
+		"groupByComponent": func(issues []jira.Issue) []Group {
+			return groupBy(issues, func(issue jira.Issue) []string {
+				var names []string
+				for _, component := range issue.Fields.Components {
+					names = append(names, component.Name)
+				}
+				return names
+			})
+		},
+
+		// Helper sui singoli ticket
+		"issueURL": func(key string) string {
+			return fmt.Sprintf("%s/browse/%s", data.BaseURL, key)
+		},
+		"statusCategory": func(issue jira.Issue) string {
+			return issue.Fields.Status.StatusCategory.Key
+		},
+		"typeEmoji": TypeEmoji,
+		"anchor":    anchor,
+		"upper":     strings.ToUpper,
+		"lower":     strings.ToLower,
+		"join":      strings.Join,
+		"trimPrefix": func(prefix, text string) string {
+			return strings.TrimPrefix(text, prefix)
+		},
 	}
+}
 
-	preferredOrder := []string{"Story", "Task", "Improvement", "Bug"}
-	typeEmoji := map[string]string{
-		"Story":       "✨",
-		"Task":        "📝",
-		"Improvement": "🔧",
-		"Bug":         "🐛",
+// TypeEmoji restituisce l'icona associata a un tipo di issue
+func TypeEmoji(issueType string) string {
+	if emoji, ok := typeEmojis[issueType]; ok {
+		return emoji
 	}
+	return "•"
+}
 
-	for _, issueType := range preferredOrder {
-		issuesList, ok := standaloneIssues[issueType]
-		if !ok || len(issuesList) == 0 {
-			continue
-		}
-
-		emoji := typeEmoji[issueType]
-		if emoji == "" {
-			emoji = "•"
+// anchor converte un testo in un identificativo utilizzabile come ancora HTML (es. "Sub-task" → "sub-task").
+// Se il testo non contiene lettere o cifre l'identificativo è ricavato da un hash del testo.
+func anchor(text string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+			dash = false
+		} else if !dash && sb.Len() > 0 {
+			sb.WriteRune('-')
+			dash = true
 		}
+	}
+	if sb.Len() == 0 {
+		h := fnv.New32a()
+		h.Write([]byte(text))
+		return fmt.Sprintf("sezione-%08x", h.Sum32())
+	}
+	return strings.TrimSuffix(sb.String(), "-")
+}
 
-		sb.WriteString(fmt.Sprintf("**%s %s**\n\n", emoji, issueType))
-
-		for _, issue := range issuesList {
-			issueURL := fmt.Sprintf("%s/browse/%s", baseURL, issue.Key)
-			sb.WriteString(fmt.Sprintf("* **[%s](%s)**: %s\n", issue.Key, issueURL, issue.Fields.Summary))
-
-			if includeSubtasks {
-				if subtasks, ok := subtaskMap[issue.Key]; ok {
-					for _, subtask := range subtasks {
-						subtaskURL := fmt.Sprintf("%s/browse/%s", baseURL, subtask.Key)
-						sb.WriteString(fmt.Sprintf("  * [%s](%s): %s\n", subtask.Key, subtaskURL, subtask.Fields.Summary))
-						printedSubtasks[subtask.Key] = true
-					}
-				}
-			}
-		}
-		sb.WriteString("\n")
+// AllIssues restituisce epic, figli e issue standalone della gerarchia (esclusi i sub-task)
+func AllIssues(hierarchy *organizer.ReleaseHierarchy) []jira.Issue {
+	var issues []jira.Issue
+	for _, epic := range hierarchy.SortedEpics() {
+		issues = append(issues, epic)
+		issues = append(issues, hierarchy.EpicChildren[epic.Key]...)
+	}
+	for _, issueType := range hierarchy.IssueTypes(PreferredOrder...) {
+		issues = append(issues, hierarchy.StandaloneIssues[issueType]...)
 	}
+	return issues
+}
 
-	for issueType, issuesList := range standaloneIssues {
-		found := false
-		for _, preferred := range preferredOrder {
-			if issueType == preferred {
-				found = true
-				break
-			}
+// groupBy raggruppa le issue secondo le chiavi estratte da keysOf, in ordine alfabetico.
+// Le issue senza chiavi finiscono in un gruppo con nome vuoto, in coda.
+func groupBy(issues []jira.Issue, keysOf func(jira.Issue) []string) []Group {
+	grouped := make(map[string][]jira.Issue)
+	for _, issue := range issues {
+		keys := keysOf(issue)
+		if len(keys) == 0 {
+			keys = []string{""}
 		}
-		if !found && len(issuesList) > 0 {
-			sb.WriteString(fmt.Sprintf("**• %s**\n\n", issueType))
-			for _, issue := range issuesList {
-				issueURL := fmt.Sprintf("%s/browse/%s", baseURL, issue.Key)
-				sb.WriteString(fmt.Sprintf("* **[%s](%s)**: %s\n", issue.Key, issueURL, issue.Fields.Summary))
-			}
-			sb.WriteString("\n")
+		for _, key := range keys {
+			grouped[key] = append(grouped[key], issue)
 		}
 	}
 
-	var orphanedSubtasks []jira.Issue
-	if includeSubtasks {
-		for _, subtasks := range subtaskMap {
-			for _, subtask := range subtasks {
-				if _, printed := printedSubtasks[subtask.Key]; !printed {
-					orphanedSubtasks = append(orphanedSubtasks, subtask)
-				}
-			}
-		}
+	names := make([]string, 0, len(grouped))
+	for name := range grouped {
+		names = append(names, name)
 	}
-
-	if len(orphanedSubtasks) > 0 {
-		sb.WriteString("**📎 Sub-task Aggiuntivi**\n\n")
-		sb.WriteString("*(Ticket con fixVersion, ma genitore non in questa release o completato)*\n\n")
-		for _, subtask := range orphanedSubtasks {
-			subtaskURL := fmt.Sprintf("%s/browse/%s", baseURL, subtask.Key)
-			sb.WriteString(fmt.Sprintf("* [%s](%s): %s\n", subtask.Key, subtaskURL, subtask.Fields.Summary))
+	sort.Slice(names, func(i, j int) bool {
+		if names[i] == "" || names[j] == "" {
+			return names[j] == ""
 		}
-		sb.WriteString("\n")
-	}
+		return names[i] < names[j]
+	})
 
-	return sb.String()
+	groups := make([]Group, 0, len(names))
+	for _, name := range names {
+		groups = append(groups, Group{Name: name, Issues: grouped[name]})
+	}
+	return groups
 }
@@ -0,0 +1,198 @@
+package templates
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"jira-release-manager/internal/jira"
+	"jira-release-manager/internal/organizer"
+)
+
+func TestRenderDefaultComment(t *testing.T) {
+	tmpl, err := ParseComment(DefaultComment)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"2.4.0", "v2.4.0"} {
+		version := &jira.Version{Name: name, ReleaseDate: "2026-10-12"}
+		data := CommentData{Data: NewData(version, nil, false, "https://jira.example.com"), Issue: jira.Issue{Key: "PROJ-1"}}
+
+		got, err := RenderComment(tmpl, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "Shipped in v2.4.0 on 2026-10-12"; got != want {
+			t.Errorf("versione %s: commento = %q, atteso %q", name, got, want)
+		}
+	}
+}
+
+func TestRenderHTMLEscapes(t *testing.T) {
+	tmpl, err := Builtin("html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issues := []jira.Issue{{Key: "PROJ-1", Fields: jira.IssueFields{
+		Summary:   `<script>alert("x")</script> & co`,
+		IssueType: jira.IssueType{Name: "Story"},
+	}}}
+	data := NewData(&jira.Version{Name: "2.4.0", ReleaseDate: "2026-10-12"}, organizer.NewReleaseHierarchy(issues, false), false, "https://jira.example.com")
+
+	// Il template deve poter essere eseguito più volte, come per i changelog di un intervallo
+	for range 2 {
+		got, err := Render(tmpl, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(got, "<script>") || !strings.Contains(got, "&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt; &amp; co") {
+			t.Fatalf("sommario non sottoposto a escaping:\n%s", got)
+		}
+		if !strings.Contains(got, `<a href="https://jira.example.com/browse/PROJ-1">PROJ-1</a>`) || !strings.Contains(got, `<section id="story">`) {
+			t.Errorf("link o ancore non attesi:\n%s", got)
+		}
+	}
+}
+
+func TestAnchor(t *testing.T) {
+	tests := map[string]string{
+		"Sub-task":         "sub-task",
+		"  Nuova  Storia ": "nuova-storia",
+		"Bug (critico)":    "bug-critico",
+	}
+	for text, want := range tests {
+		if got := anchor(text); got != want {
+			t.Errorf("anchor(%q) = %q, atteso %q", text, got, want)
+		}
+	}
+
+	// Senza lettere né cifre l'ancora è generata, stabile e diversa per testi diversi
+	first, second := anchor("🐞"), anchor("✨ ✨")
+	if !strings.HasPrefix(first, "sezione-") || first != anchor("🐞") || first == second {
+		t.Errorf("ancore generate: %q, %q", first, second)
+	}
+}
+
+// update rigenera i file golden: go test ./internal/templates -update
+var update = flag.Bool("update", false, "rigenera i file golden in testdata")
+
+// newTestData crea una release con un epic (figlio e sub-task), issue standalone di tipi
+// predefiniti e personalizzati e un sub-task il cui genitore non è nella release
+func newTestData() Data {
+	issue := func(key, summary, issueType string, labels []string, components ...string) jira.Issue {
+		i := jira.Issue{Key: key, Fields: jira.IssueFields{Summary: summary, IssueType: jira.IssueType{Name: issueType}, Labels: labels}}
+		for _, name := range components {
+			i.Fields.Components = append(i.Fields.Components, jira.Component{Name: name})
+		}
+		return i
+	}
+	subtask := func(key, summary, parent string) jira.Issue {
+		i := issue(key, summary, "Sub-task", nil)
+		i.Fields.IssueType.Subtask = true
+		i.Fields.Parent = &jira.IssueRef{Key: parent}
+		return i
+	}
+	story := issue("PROJ-2", "Login con SSO", "Story", []string{"backend", "auth"}, "API")
+	story.Fields.Parent = &jira.IssueRef{Key: "PROJ-1"}
+
+	issues := []jira.Issue{
+		issue("PROJ-1", "Autenticazione", "Epic", nil),
+		story,
+		subtask("PROJ-3", "Configurare l'IdP", "PROJ-2"),
+		issue("PROJ-4", "Crash all'avvio", "Bug", []string{"backend"}, "API", "Mobile"),
+		issue("PROJ-5", "Aggiornare le dipendenze", "Task", nil),
+		issue("PROJ-6", "Valutare la cache", "Spike", nil),
+		issue("PROJ-7", "Pagina di stato", "Story", []string{"frontend"}),
+		subtask("PROJ-8", "Documentazione", "PROJ-99"),
+	}
+	version := &jira.Version{Name: "2.4.0", ReleaseDate: "2026-10-12", Description: "Single sign-on"}
+	return NewData(version, organizer.NewReleaseHierarchy(issues, false), true, "https://jira.example.com")
+}
+
+func TestRenderBuiltinGolden(t *testing.T) {
+	for _, format := range []string{"markdown", "teams"} {
+		tmpl, err := Builtin(format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := Render(tmpl, newTestData())
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+
+		golden := filepath.Join("testdata", format+".golden")
+		if *update {
+			if err := os.WriteFile(golden, []byte(got), 0644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		want, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != string(want) {
+			t.Errorf("%s: output diverso da %s (rigenera con -update se la modifica è voluta):\n%s", format, golden, got)
+		}
+	}
+}
+
+func TestStandaloneOrder(t *testing.T) {
+	var names []string
+	for _, group := range funcMap(newTestData())["standalone"].(func() []Group)() {
+		names = append(names, group.Name)
+	}
+	// Prima i tipi di PreferredOrder, poi gli altri in ordine alfabetico
+	if want := []string{"Story", "Task", "Bug", "Spike"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("standalone = %v, attesi %v", names, want)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	data := newTestData()
+	funcs := funcMap(data)
+	issues := AllIssues(data.Hierarchy)
+
+	summarize := func(groups []Group) map[string][]string {
+		out := make(map[string][]string)
+		var order []string
+		for _, group := range groups {
+			order = append(order, group.Name)
+			for _, issue := range group.Issues {
+				out[group.Name] = append(out[group.Name], issue.Key)
+			}
+		}
+		out["ordine"] = order
+		return out
+	}
+
+	tests := []struct {
+		name string
+		fn   string
+		want map[string][]string
+	}{
+		{"groupByType", "groupByType", map[string][]string{
+			"ordine": {"Bug", "Epic", "Spike", "Story", "Task"},
+			"Bug":    {"PROJ-4"}, "Epic": {"PROJ-1"}, "Spike": {"PROJ-6"}, "Story": {"PROJ-2", "PROJ-7"}, "Task": {"PROJ-5"},
+		}},
+		{"groupByLabel", "groupByLabel", map[string][]string{
+			"ordine": {"auth", "backend", "frontend", ""},
+			"auth":   {"PROJ-2"}, "backend": {"PROJ-2", "PROJ-4"}, "frontend": {"PROJ-7"}, "": {"PROJ-1", "PROJ-5", "PROJ-6"},
+		}},
+		{"groupByComponent", "groupByComponent", map[string][]string{
+			"ordine": {"API", "Mobile", ""},
+			"API":    {"PROJ-2", "PROJ-4"}, "Mobile": {"PROJ-4"}, "": {"PROJ-1", "PROJ-7", "PROJ-5", "PROJ-6"},
+		}},
+	}
+	for _, tt := range tests {
+		got := summarize(funcs[tt.fn].(func([]jira.Issue) []Group)(issues))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s = %v, attesi %v", tt.name, got, tt.want)
+		}
+	}
+}